@@ -0,0 +1,158 @@
+package archerc9v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/url"
+	"strconv"
+)
+
+const (
+	accessControlPage = "userRpm/AccessCtrlBlackListRpm.htm"
+
+	// denyListedPolicy is the router's code for the access control policy that
+	// lets every node through except the ones on the blacklist.
+	denyListedPolicy = 0
+)
+
+// BlockedDevice represents an entry of the router's access control blacklist,
+// which keeps the node with the given MAC address from reaching the router and
+// the Internet over both wired and wireless connections. The ID is the position
+// of the entry in the router's list, counting across all of its pages.
+type BlockedDevice struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	MacAddress string `json:"mac_addr"`
+
+	// pos is where the entry is shown on the router's access control pages.
+	pos listPosition
+}
+
+// ListBlockedDevices wraps ListBlockedDevicesContext using context.Background.
+func (c *Client) ListBlockedDevices() ([]*BlockedDevice, error) {
+	return c.ListBlockedDevicesContext(context.Background())
+}
+
+// ListBlockedDevicesContext returns the entries of the router's access control
+// blacklist or returns an error otherwise.
+func (c *Client) ListBlockedDevicesContext(ctx context.Context) ([]*BlockedDevice, error) {
+	rows, err := c.getListRows(ctx, accessControlPage, "list blocked devices",
+		parseListRows("blackList", 2, "access control page"))
+	if err != nil {
+		return nil, err
+	}
+
+	var devices []*BlockedDevice
+	for i, row := range rows {
+		devices = append(devices, &BlockedDevice{ID: i, Name: row.strAt(0), MacAddress: row.strAt(1), pos: row.pos})
+	}
+	return devices, nil
+}
+
+// BlockDevice wraps BlockDeviceContext using context.Background.
+func (c *Client) BlockDevice(macAddress, name string) error {
+	return c.BlockDeviceContext(context.Background(), macAddress, name)
+}
+
+// BlockDeviceContext adds the node with the given MAC address, which may be in any
+// format accepted by NormalizeMAC, to the router's access control blacklist under
+// the given name. Access control is turned on in blacklist mode if it is off. An
+// error is returned if the MAC address is already blocked or if access control is
+// on in whitelist mode, where the blacklist has no effect.
+func (c *Client) BlockDeviceContext(ctx context.Context, macAddress, name string) error {
+	mac, err := NormalizeMAC(macAddress)
+	if err != nil {
+		return err
+	}
+
+	data, err := c.readPage(ctx, accessControlPage, nil, "get access control settings")
+	if err != nil {
+		return err
+	}
+	para, err := parseJSArray(data, "accessCtrlPara")
+	if err != nil {
+		return errors.Wrap(err, "got error parsing access control page")
+	}
+	enabled, err := para.boolAt(0)
+	if err != nil {
+		return errors.Wrap(err, "got invalid access control state")
+	}
+	policy, err := para.intAt(1)
+	if err != nil {
+		return errors.Wrap(err, "got invalid access control policy")
+	}
+	if enabled && policy != denyListedPolicy {
+		return errors.New("got access control in whitelist mode (want access control off or in blacklist mode)")
+	}
+
+	d, err := c.findBlockedDevice(ctx, mac)
+	if err != nil {
+		return err
+	}
+	if d != nil {
+		return fmt.Errorf("got MAC address %s that is already blocked as %q (want an unblocked MAC address)", mac, d.Name)
+	}
+
+	q := url.Values{}
+	q.Set("Name", name)
+	q.Set("Mac", mac)
+	q.Set("Page", "1")
+	q.Set("Add", "Add")
+	if _, err = c.getPage(ctx, accessControlPage, q, "block device"); err != nil {
+		return err
+	}
+	if enabled {
+		return nil
+	}
+
+	q = url.Values{}
+	q.Set("enable", "1")
+	q.Set("policy", strconv.Itoa(denyListedPolicy))
+	q.Set("Save", "Save")
+	_, err = c.getPage(ctx, accessControlPage, q, "turn on access control")
+	return err
+}
+
+// UnblockDevice wraps UnblockDeviceContext using context.Background.
+func (c *Client) UnblockDevice(macAddress string) error {
+	return c.UnblockDeviceContext(context.Background(), macAddress)
+}
+
+// UnblockDeviceContext deletes the node with the given MAC address, which may be in
+// any format accepted by NormalizeMAC, from the router's access control blacklist.
+// An error is returned if the MAC address is not blocked.
+func (c *Client) UnblockDeviceContext(ctx context.Context, macAddress string) error {
+	mac, err := NormalizeMAC(macAddress)
+	if err != nil {
+		return err
+	}
+
+	d, err := c.findBlockedDevice(ctx, mac)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return fmt.Errorf("got MAC address %s that is not blocked (want a blocked MAC address)", mac)
+	}
+
+	q := url.Values{}
+	d.pos.set(q, "Del")
+	_, err = c.getPage(ctx, accessControlPage, q, "unblock device")
+	return err
+}
+
+// findBlockedDevice returns the blacklist entry for the normalized MAC address
+// mac or nil if there is none.
+func (c *Client) findBlockedDevice(ctx context.Context, mac string) (*BlockedDevice, error) {
+	devices, err := c.ListBlockedDevicesContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error listing blocked devices")
+	}
+	for _, d := range devices {
+		if m, _ := NormalizeMAC(d.MacAddress); m == mac {
+			return d, nil
+		}
+	}
+	return nil, nil
+}
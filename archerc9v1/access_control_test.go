@@ -0,0 +1,172 @@
+package archerc9v1
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var validAccessControlPage = `<script type="text/javascript">
+var accessCtrlPara = new Array(
+0,
+0,
+0,0 );
+var blackList = new Array(
+"noisy-laptop", "12-34-56-AA-BB-CC",
+0,0 );
+</script>`
+
+var validAccessControlPage2 = `<script type="text/javascript">
+var accessCtrlPara = new Array(
+0,
+0,
+0,0 );
+var blackList = new Array(
+"game-console", "3C-3C-3C-CC-CC-CC",
+0,0 );
+</script>`
+
+// newAccessControlTestClient returns a Client for a router serving the given
+// access control pages, along with a pointer to the queries of the requests
+// that changed the access control settings.
+func newAccessControlTestClient(pages ...string) (*Client, *[]url.Values) {
+	changes := new([]url.Values)
+	serve := serveListPages(accessControlPage, pages...)
+	return newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		q := r.URL.Query()
+		if len(q) > 1 {
+			*changes = append(*changes, q)
+		}
+		return serve(r)
+	}), changes
+}
+
+func TestClient_ListBlockedDevices(t *testing.T) {
+	client, _ = newAccessControlTestClient(validAccessControlPage)
+	expected := []*BlockedDevice{{ID: 0, Name: "noisy-laptop", MacAddress: "12-34-56-AA-BB-CC",
+		pos: listPosition{page: 1, index: 0}}}
+	got, err := client.ListBlockedDevices()
+	if err != nil {
+		t.Fatalf("FAIL: Valid response\n\t%v.ListBlockedDevices() returned an unexpected error: %v", client, err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("FAIL: Valid response\n\t%v.ListBlockedDevices() returned %v, want %v", client, got, expected)
+	}
+	t.Logf("PASS: Valid response")
+
+	client, _ = newAccessControlTestClient(validAccessControlPage, validAccessControlPage2)
+	expected = append(expected, &BlockedDevice{ID: 1, Name: "game-console", MacAddress: "3C-3C-3C-CC-CC-CC",
+		pos: listPosition{page: 2, index: 0}})
+	got, err = client.ListBlockedDevices()
+	if err != nil {
+		t.Fatalf("FAIL: Two pages\n\t%v.ListBlockedDevices() returned an unexpected error: %v", client, err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("FAIL: Two pages\n\t%v.ListBlockedDevices() returned %v, want %v", client, got, expected)
+	}
+	t.Logf("PASS: Two pages")
+}
+
+func TestClient_BlockDevice(t *testing.T) {
+	block := url.Values{"Name": {"tablet"}, "Mac": {"22-22-22-AA-AA-AA"}, "Page": {"1"}, "Add": {"Add"}}
+	testCases := []*struct {
+		description string
+		pages       []string
+		input       string
+		expected    []url.Values
+		expectError bool
+	}{
+		{
+			description: "Invalid MAC address",
+			pages:       []string{validAccessControlPage},
+			input:       "12-34",
+			expectError: true,
+		},
+		{
+			description: "Already blocked MAC address",
+			pages:       []string{validAccessControlPage},
+			input:       "12:34:56:aa:bb:cc",
+			expectError: true,
+		},
+		{
+			description: "MAC address blocked on second page",
+			pages:       []string{validAccessControlPage, validAccessControlPage2},
+			input:       "3c3c3ccccccc",
+			expectError: true,
+		},
+		{
+			description: "Access control in whitelist mode",
+			pages:       []string{`var accessCtrlPara = new Array(1, 1, 0,0 ); var blackList = new Array(0,0 );`},
+			input:       "22-22-22-AA-AA-AA",
+			expectError: true,
+		},
+		{
+			description: "Access control on in blacklist mode",
+			pages:       []string{`var accessCtrlPara = new Array(1, 0, 0,0 ); var blackList = new Array(0,0 );`},
+			input:       "222222aaaaaa",
+			expected:    []url.Values{block},
+		},
+		{
+			description: "Access control off",
+			pages:       []string{validAccessControlPage},
+			input:       "22-22-22-aa-aa-aa",
+			expected: []url.Values{
+				block,
+				{"enable": {"1"}, "policy": {"0"}, "Save": {"Save"}},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		var changes *[]url.Values
+		client, changes = newAccessControlTestClient(tt.pages...)
+		err := client.BlockDevice(tt.input, "tablet")
+		if tt.expectError {
+			if err == nil || len(*changes) != 0 {
+				t.Fatalf("FAIL: %s\n\t%v.BlockDevice(%s) returned %v and submitted %v, want an error and nothing submitted",
+					tt.description, client, tt.input, err, *changes)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.BlockDevice(%s) returned an unexpected error: %v",
+					tt.description, client, tt.input, err)
+			}
+			if !reflect.DeepEqual(*changes, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.BlockDevice(%s) submitted %v, want %v",
+					tt.description, client, tt.input, *changes, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_UnblockDevice(t *testing.T) {
+	var changes *[]url.Values
+	client, changes = newAccessControlTestClient(validAccessControlPage, validAccessControlPage2)
+	if err := client.UnblockDevice("22-22-22-AA-AA-AA"); err == nil {
+		t.Fatalf("FAIL: Unblocked MAC address\n\t%v.UnblockDevice() did not return an expected error", client)
+	}
+	t.Logf("PASS: Unblocked MAC address")
+
+	if err := client.UnblockDevice("12:34:56:aa:bb:cc"); err != nil {
+		t.Fatalf("FAIL: Blocked MAC address\n\t%v.UnblockDevice() returned an unexpected error: %v", client, err)
+	}
+	expected := []url.Values{{"Del": {"0"}, "Page": {"1"}}}
+	if !reflect.DeepEqual(*changes, expected) {
+		t.Fatalf("FAIL: Blocked MAC address\n\t%v.UnblockDevice() submitted %v, want %v", client, *changes, expected)
+	}
+	t.Logf("PASS: Blocked MAC address")
+
+	*changes = nil
+	if err := client.UnblockDevice("3c:3c:3c:cc:cc:cc"); err != nil {
+		t.Fatalf("FAIL: MAC address blocked on second page\n\t%v.UnblockDevice() returned an unexpected error: %v",
+			client, err)
+	}
+	expected = []url.Values{{"Del": {"0"}, "Page": {"2"}}}
+	if !reflect.DeepEqual(*changes, expected) {
+		t.Fatalf("FAIL: MAC address blocked on second page\n\t%v.UnblockDevice() submitted %v, want %v",
+			client, *changes, expected)
+	}
+	t.Logf("PASS: MAC address blocked on second page")
+}
@@ -1,16 +1,26 @@
 package archerc9v1
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"github.com/pkg/errors"
 	"io/ioutil"
+	"net/http"
+	"net/url"
 	"strings"
+	"time"
 )
 
-// Reboot reboots the router and returns nil if the reboot is successful.
-// Otherwise, an error is returned.
+// Reboot wraps RebootContext using context.Background.
 func (c *Client) Reboot() error {
-	req, err := c.NewRequest(
+	return c.RebootContext(context.Background())
+}
+
+// RebootContext reboots the router and returns nil if the reboot is successful.
+// Otherwise, an error is returned.
+func (c *Client) RebootContext(ctx context.Context) error {
+	req, err := c.NewRequestWithContext(ctx,
 		"GET", "userRpm/SysRebootRpm.htm", nil)
 	if err != nil {
 		return errors.Wrap(err, "got error creating request to do reboot")
@@ -19,8 +29,7 @@ func (c *Client) Reboot() error {
 	q.Set("Reboot", "Reboot")
 	req.URL.RawQuery = q.Encode()
 
-	c.logger.Printf("sending reboot request as (%s %s) ...",
-		req.Method, req.URL)
+	c.logger.Info("sending reboot request", "method", req.Method, "url", redactURL(req.URL))
 	resp, err := c.Do(req)
 	if resp != nil {
 		defer resp.Body.Close()
@@ -39,15 +48,287 @@ func (c *Client) Reboot() error {
 	}
 
 	if len(data) == 0 {
-		return errors.New("got empty body in response to reboot")
+		return newUnexpectedResponseError(resp, nil, "got empty body in response to reboot")
 	}
 
+	if err = checkBody(data); err != nil {
+		return errors.Wrap(err, "got error in response to reboot")
+	}
 	if !strings.Contains(string(data), "Rebooting...") ||
 		!strings.Contains(string(data), "Completed!") {
-		return fmt.Errorf("got invalid response body (want response indicating rebooting has completed): %s",
-			string(data))
+		return newUnexpectedResponseError(resp, data,
+			"got invalid response body (want response indicating rebooting has completed)")
 	}
 
-	c.logger.Printf("reboot completed successfully...response from reboot call: %s", string(data))
+	c.logger.Info("reboot completed successfully")
+	c.logger.Debug("got response to reboot", "body", string(data))
 	return nil
 }
+
+// statusPollInterval is how often the router is polled while waiting for a WAN
+// action or a restart to take effect.
+var statusPollInterval = 2 * time.Second
+
+// RenewWAN wraps RenewWANContext using context.Background.
+func (c *Client) RenewWAN(timeout time.Duration) (*WANStatus, error) {
+	return c.RenewWANContext(context.Background(), timeout)
+}
+
+// RenewWANContext asks the ISP's DHCP server to renew the lease of the router's WAN
+// address and waits up to timeout for the WAN interface to lose its address and get
+// one again, or to get a different address than before. Since a renewed lease
+// usually keeps the same address without a visible gap, the WAN interface still
+// having an address when the timeout elapses also counts as success. It returns
+// the resulting WAN status, or nil if timeout is 0 and it returns without waiting.
+// An error is returned if the WAN connection type is not dynamic or the WAN
+// interface has no address when the timeout elapses.
+func (c *Client) RenewWANContext(ctx context.Context, timeout time.Duration) (*WANStatus, error) {
+	return c.doWANAction(ctx, WANDynamicIP, "RenewIp", "Renew", "renew WAN lease", timeout, reconnected,
+		(*WANStatus).Connected)
+}
+
+// ReleaseWAN wraps ReleaseWANContext using context.Background.
+func (c *Client) ReleaseWAN(timeout time.Duration) (*WANStatus, error) {
+	return c.ReleaseWANContext(context.Background(), timeout)
+}
+
+// ReleaseWANContext releases the lease of the router's WAN address and waits up to
+// timeout for the WAN interface to lose its address. It returns the resulting
+// WAN status, or nil if timeout is 0 and it returns without waiting. An error is
+// returned if the WAN connection type is not dynamic or the timeout elapses.
+func (c *Client) ReleaseWANContext(ctx context.Context, timeout time.Duration) (*WANStatus, error) {
+	return c.doWANAction(ctx, WANDynamicIP, "ReleaseIp", "Release", "release WAN lease", timeout,
+		disconnected, nil)
+}
+
+// ConnectPPPoE wraps ConnectPPPoEContext using context.Background.
+func (c *Client) ConnectPPPoE(timeout time.Duration) (*WANStatus, error) {
+	return c.ConnectPPPoEContext(context.Background(), timeout)
+}
+
+// ConnectPPPoEContext dials the router's PPPoE connection and waits up to timeout
+// for the WAN interface to lose its address and get one again, or to get a
+// different address than before. A connection that is already up may keep its
+// address, so the WAN interface still having an address when the timeout elapses
+// also counts as success. It returns the resulting WAN status, or nil if timeout
+// is 0 and it returns without waiting. An error is returned if the WAN connection
+// type is not pppoe or the WAN interface has no address when the timeout elapses.
+func (c *Client) ConnectPPPoEContext(ctx context.Context, timeout time.Duration) (*WANStatus, error) {
+	return c.doWANAction(ctx, WANPPPoE, "Connect", "Connect", "connect PPPoE", timeout, reconnected,
+		(*WANStatus).Connected)
+}
+
+// DisconnectPPPoE wraps DisconnectPPPoEContext using context.Background.
+func (c *Client) DisconnectPPPoE(timeout time.Duration) (*WANStatus, error) {
+	return c.DisconnectPPPoEContext(context.Background(), timeout)
+}
+
+// DisconnectPPPoEContext hangs up the router's PPPoE connection and waits up to
+// timeout for the WAN interface to lose its address. It returns the resulting WAN
+// status, or nil if timeout is 0 and it returns without waiting. An error is
+// returned if the WAN connection type is not pppoe or the timeout elapses.
+func (c *Client) DisconnectPPPoEContext(ctx context.Context, timeout time.Duration) (*WANStatus, error) {
+	return c.doWANAction(ctx, WANPPPoE, "Disconnect", "Disconnect", "disconnect PPPoE", timeout,
+		disconnected, nil)
+}
+
+// reconnected reports whether the WAN interface has an address other than
+// before, which doWANAction sets to "" once the interface lost its address.
+func reconnected(before string, w *WANStatus) bool {
+	return w.Connected() && w.IPAddress != before
+}
+
+// disconnected reports whether the WAN interface has no address.
+func disconnected(_ string, w *WANStatus) bool {
+	return !w.Connected()
+}
+
+// doWANAction presses the button with the given name and value on the router's
+// status page if the WAN connection type is t, then polls the status page until
+// done reports true for the WAN status or the timeout elapses. Besides the WAN
+// status, done gets the WAN IP address from before the button was pressed, or ""
+// if the WAN interface had no address then or has lost it since. If settled is
+// not nil and reports true for the WAN status when the timeout elapses, that
+// status is returned instead of an error.
+func (c *Client) doWANAction(ctx context.Context, t WANConnectionType, button, value, action string,
+	timeout time.Duration, done func(before string, w *WANStatus) bool,
+	settled func(*WANStatus) bool) (*WANStatus, error) {
+	if timeout < 0 {
+		return nil, fmt.Errorf("got timeout %s (want 0 or more)", timeout)
+	}
+	status, err := c.GetStatusContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error getting WAN status before trying to "+action)
+	}
+	if status.WAN.ConnectionType != t {
+		return nil, fmt.Errorf("got WAN connection type %s (want %s to %s)", status.WAN.ConnectionType, t, action)
+	}
+	before := status.WAN.IPAddress
+
+	q := url.Values{}
+	q.Set(button, value)
+	q.Set("wan", "1")
+	if _, err = c.getPage(ctx, statusPage, q, action); err != nil {
+		return nil, err
+	}
+	if timeout == 0 {
+		return nil, nil
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		if err = sleepContext(ctx, statusPollInterval); err != nil {
+			return nil, errors.Wrap(err, "got canceled while waiting to "+action)
+		}
+		status, err = c.GetStatusContext(ctx)
+		if err == nil {
+			if !status.WAN.Connected() {
+				before = ""
+			}
+			if done(before, status.WAN) {
+				return status.WAN, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("got no result within %s of trying to %s", timeout, action))
+			}
+			if settled != nil && settled(status.WAN) {
+				return status.WAN, nil
+			}
+			return status.WAN, fmt.Errorf("got WAN IP address %q %s after trying to %s (want the action to take effect)",
+				status.WAN.IPAddress, timeout, action)
+		}
+	}
+}
+
+// sleepContext pauses for d or until ctx is done, in which case it returns
+// ctx's error.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// restartProbeTimeout bounds each request sent to find out whether the router
+// is up while waiting for it to restart, so that a router that stopped
+// answering mid-request counts as down.
+var restartProbeTimeout = 5 * time.Second
+
+// RebootAndWait reboots the router and waits up to timeout for it to go down and
+// come back up, and returns how long the router was seen to be down. Unlike
+// WaitForRestart, the router only counts as up again once it accepts the
+// client's credentials and lists its wired connections, i.e. once it is usable.
+// An error is returned if the reboot fails, the router does not come back within
+// the timeout or ctx is done first.
+func (c *Client) RebootAndWait(ctx context.Context, timeout time.Duration) (time.Duration, error) {
+	if timeout <= 0 {
+		return 0, fmt.Errorf("got timeout %s (want more than 0)", timeout)
+	}
+	if err := c.RebootContext(ctx); err != nil {
+		return 0, err
+	}
+	return c.waitForRestart(ctx, timeout, c.isUsable)
+}
+
+// WaitForRestart wraps WaitForRestartContext using context.Background.
+func (c *Client) WaitForRestart(timeout time.Duration) (time.Duration, error) {
+	return c.WaitForRestartContext(context.Background(), timeout)
+}
+
+// WaitForRestartContext waits up to timeout for the router to go down and come back
+// up after an action that restarts it, e.g. RestoreConfig, and returns how long the
+// router was seen to be down. The router counts as up as long as its status page
+// can be retrieved. An error is returned if the router does not go down and come
+// back up within the timeout.
+func (c *Client) WaitForRestartContext(ctx context.Context, timeout time.Duration) (time.Duration, error) {
+	if timeout <= 0 {
+		return 0, fmt.Errorf("got timeout %s (want more than 0)", timeout)
+	}
+	return c.waitForRestart(ctx, timeout, c.isUp)
+}
+
+// waitForRestart polls the router with up until it reports the router to be
+// down and then up again and returns how long the router was down.
+func (c *Client) waitForRestart(ctx context.Context, timeout time.Duration,
+	up func(context.Context) bool) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for up(ctx) {
+		if err := sleepContext(ctx, statusPollInterval); err != nil {
+			return 0, errors.Wrap(err, "got router still up after it should have restarted (want it to go down)")
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		// The probe failed because the wait is over, not because the router went down.
+		return 0, errors.Wrap(err, "got router still up after it should have restarted (want it to go down)")
+	}
+
+	down := time.Now()
+	c.logger.Info("router is down, waiting for it to come back up")
+	for !up(ctx) {
+		if err := sleepContext(ctx, statusPollInterval); err != nil {
+			return time.Since(down), errors.Wrap(err, "got router still down after it should have restarted (want it back up)")
+		}
+	}
+	return time.Since(down), nil
+}
+
+// isUp reports whether the router's status page can be retrieved within
+// restartProbeTimeout.
+func (c *Client) isUp(ctx context.Context) bool {
+	_, err := c.probe(ctx, "GET", statusPage)
+	return err == nil
+}
+
+// isUsable reports whether the router's status page can be retrieved with the
+// client's credentials and the router lists its wired connections, which it
+// only does once it has fully started.
+func (c *Client) isUsable(ctx context.Context) bool {
+	if _, err := c.probe(ctx, "GET", statusPage); err != nil {
+		return false
+	}
+	resp, err := c.probe(ctx, "POST", wiredConnectionsPage)
+	if err != nil {
+		return false
+	}
+	_, err = (&connectionsResponse{response: resp, transport: "wired"}).getConnections()
+	return err == nil
+}
+
+// probe sends a request for the page at urlStr that is canceled after
+// restartProbeTimeout or when ctx is done, and returns the response with its
+// body read, or an error if the request fails or the response is not a
+// successful one. The request is not marked as a read and therefore not retried,
+// since failures are expected while the router restarts.
+func (c *Client) probe(ctx context.Context, method, urlStr string) (*http.Response, error) {
+	req, err := c.NewRequestWithContext(ctx, method, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, restartProbeTimeout)
+	defer cancel()
+	resp, err := c.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err = CheckResponse(resp); err != nil {
+		return nil, err
+	}
+	if err = checkBody(data); err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+	return resp, nil
+}
@@ -1,11 +1,15 @@
 package archerc9v1 
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestClient_Reboot(t *testing.T) {
@@ -90,7 +94,7 @@ func TestClient_Reboot(t *testing.T) {
 		client = &Client{
 			baseURL:    validURL,
 			httpClient: NewTestClient(tt.input),
-			logger:     defaultLogger}
+			logger:     testLogger}
 		err := client.Reboot()
 		if tt.expectError {
 			if err == nil {
@@ -104,3 +108,339 @@ func TestClient_Reboot(t *testing.T) {
 		t.Logf("PASS: %s", tt.description)
 	}
 }
+
+// newWANActionTestClient returns a Client for a router whose status page shows
+// connection type code typeCode and the WAN IP address ip. Pressing a status page
+// button makes the router show the space separated IP addresses in after, one per
+// poll of the status page, from within polls on, repeating the last one. The
+// returned pointer holds the query of the last button press.
+func newWANActionTestClient(typeCode, ip, after string, within int) (*Client, *url.Values) {
+	pressed := new(url.Values)
+	polls := -1
+	return newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		if q := r.URL.Query(); len(q) > 0 {
+			*pressed = q
+			polls = 0
+		} else if polls >= 0 {
+			polls++
+		}
+		shown := ip
+		if addrs := strings.Fields(after); polls >= within && len(addrs) > 0 {
+			i := polls - within
+			if i >= len(addrs) {
+				i = len(addrs) - 1
+			}
+			shown = addrs[i]
+		}
+		page := strings.Replace(strings.Replace(validStatusPage,
+			"var wanPara = new Array(\n0,", "var wanPara = new Array(\n"+typeCode+",", 1),
+			`"203.0.113.7"`, `"`+shown+`"`, 1)
+		return newPageResponse(r, 200, page), nil
+	}), pressed
+}
+
+func TestClient_WANActions(t *testing.T) {
+	statusPollInterval = time.Millisecond
+	defer func() { statusPollInterval = 2 * time.Second }()
+
+	testCases := []*struct {
+		description string
+		action      func(*Client, time.Duration) (*WANStatus, error)
+		typeCode    string
+		ip, after   string
+		within      int
+		timeout     time.Duration
+		expected    url.Values
+		expectError bool
+	}{
+		{
+			description: "Renew on PPPoE connection",
+			action:      (*Client).RenewWAN,
+			typeCode:    "2",
+			ip:          "203.0.113.7",
+			timeout:     time.Second,
+			expectError: true,
+		},
+		{
+			description: "Renew without waiting",
+			action:      (*Client).RenewWAN,
+			typeCode:    "0",
+			ip:          "0.0.0.0",
+			expected:    url.Values{"RenewIp": {"Renew"}, "wan": {"1"}},
+		},
+		{
+			description: "Renew gets address",
+			action:      (*Client).RenewWAN,
+			typeCode:    "0",
+			ip:          "0.0.0.0",
+			after:       "203.0.113.8",
+			within:      3,
+			timeout:     time.Second,
+			expected:    url.Values{"RenewIp": {"Renew"}, "wan": {"1"}},
+		},
+		{
+			description: "Renew keeps address throughout",
+			action:      (*Client).RenewWAN,
+			typeCode:    "0",
+			ip:          "203.0.113.7",
+			after:       "203.0.113.7",
+			within:      1,
+			timeout:     10 * time.Millisecond,
+			expected:    url.Values{"RenewIp": {"Renew"}, "wan": {"1"}},
+		},
+		{
+			description: "Renew loses address for good",
+			action:      (*Client).RenewWAN,
+			typeCode:    "0",
+			ip:          "203.0.113.7",
+			after:       "0.0.0.0",
+			within:      1,
+			timeout:     10 * time.Millisecond,
+			expectError: true,
+		},
+		{
+			description: "Renew loses address and gets it again",
+			action:      (*Client).RenewWAN,
+			typeCode:    "0",
+			ip:          "203.0.113.7",
+			after:       "0.0.0.0 203.0.113.7",
+			within:      2,
+			timeout:     time.Second,
+			expected:    url.Values{"RenewIp": {"Renew"}, "wan": {"1"}},
+		},
+		{
+			description: "Renew gets different address",
+			action:      (*Client).RenewWAN,
+			typeCode:    "0",
+			ip:          "203.0.113.7",
+			after:       "203.0.113.8",
+			within:      1,
+			timeout:     time.Second,
+			expected:    url.Values{"RenewIp": {"Renew"}, "wan": {"1"}},
+		},
+		{
+			description: "Release times out",
+			action:      (*Client).ReleaseWAN,
+			typeCode:    "0",
+			ip:          "203.0.113.7",
+			after:       "203.0.113.7",
+			within:      1,
+			timeout:     10 * time.Millisecond,
+			expectError: true,
+		},
+		{
+			description: "Release loses address",
+			action:      (*Client).ReleaseWAN,
+			typeCode:    "0",
+			ip:          "203.0.113.7",
+			after:       "0.0.0.0",
+			within:      2,
+			timeout:     time.Second,
+			expected:    url.Values{"ReleaseIp": {"Release"}, "wan": {"1"}},
+		},
+		{
+			description: "Connect on dynamic connection",
+			action:      (*Client).ConnectPPPoE,
+			typeCode:    "0",
+			ip:          "0.0.0.0",
+			timeout:     time.Second,
+			expectError: true,
+		},
+		{
+			description: "Connect gets address",
+			action:      (*Client).ConnectPPPoE,
+			typeCode:    "2",
+			ip:          "0.0.0.0",
+			after:       "203.0.113.8",
+			within:      1,
+			timeout:     time.Second,
+			expected:    url.Values{"Connect": {"Connect"}, "wan": {"1"}},
+		},
+		{
+			description: "Connect keeps address throughout",
+			action:      (*Client).ConnectPPPoE,
+			typeCode:    "2",
+			ip:          "203.0.113.7",
+			after:       "203.0.113.7",
+			within:      1,
+			timeout:     10 * time.Millisecond,
+			expected:    url.Values{"Connect": {"Connect"}, "wan": {"1"}},
+		},
+		{
+			description: "Disconnect loses address",
+			action:      (*Client).DisconnectPPPoE,
+			typeCode:    "2",
+			ip:          "203.0.113.7",
+			after:       "0.0.0.0",
+			within:      1,
+			timeout:     time.Second,
+			expected:    url.Values{"Disconnect": {"Disconnect"}, "wan": {"1"}},
+		},
+	}
+
+	for _, tt := range testCases {
+		var pressed *url.Values
+		client, pressed = newWANActionTestClient(tt.typeCode, tt.ip, tt.after, tt.within)
+		got, err := tt.action(client, tt.timeout)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tWAN action did not return an expected error", tt.description)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\tWAN action returned an unexpected error: %v", tt.description, err)
+			}
+			if !reflect.DeepEqual(*pressed, tt.expected) {
+				t.Fatalf("FAIL: %s\n\tWAN action submitted %v, want %v", tt.description, *pressed, tt.expected)
+			}
+			if tt.timeout == 0 && got != nil {
+				t.Fatalf("FAIL: %s\n\tWAN action returned %+v, want nil without waiting", tt.description, got)
+			}
+			if addrs := strings.Fields(tt.after); tt.timeout != 0 &&
+				got.IPAddress != emptyIfUnset(addrs[len(addrs)-1]) {
+				t.Fatalf("FAIL: %s\n\tWAN action returned IP address %q, want %q",
+					tt.description, got.IPAddress, emptyIfUnset(addrs[len(addrs)-1]))
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_WaitForRestart(t *testing.T) {
+	statusPollInterval = time.Millisecond
+	defer func() { statusPollInterval = 2 * time.Second }()
+
+	testCases := []*struct {
+		description string
+		upPolls     int
+		downPolls   int
+		expectError bool
+	}{
+		{
+			description: "Router restarts",
+			upPolls:     2,
+			downPolls:   3,
+		},
+		{
+			description: "Router is already down",
+			downPolls:   1,
+		},
+		{
+			description: "Router never goes down",
+			upPolls:     1 << 30,
+			expectError: true,
+		},
+		{
+			description: "Router never comes back",
+			upPolls:     1,
+			downPolls:   1 << 30,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		polls := 0
+		client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+			polls++
+			if polls > tt.upPolls && polls <= tt.upPolls+tt.downPolls {
+				return nil, fmt.Errorf("connection refused")
+			}
+			return newPageResponse(r, 200, validStatusPage), nil
+		})
+		_, err := client.WaitForRestart(50 * time.Millisecond)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.WaitForRestart() did not return an expected error", tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.WaitForRestart() returned an unexpected error: %v", tt.description, client, err)
+			}
+			if want := tt.upPolls + tt.downPolls + 1; polls != want {
+				t.Fatalf("FAIL: %s\n\t%v.WaitForRestart() polled the router %d times, want %d",
+					tt.description, client, polls, want)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_RebootAndWait(t *testing.T) {
+	statusPollInterval = time.Millisecond
+	defer func() { statusPollInterval = 2 * time.Second }()
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := []*struct {
+		description   string
+		ctx           context.Context
+		rebootPage    string
+		downPolls     int
+		startingPolls int
+		expectError   bool
+	}{
+		{
+			description: "Reboot fails",
+			ctx:         context.Background(),
+			rebootPage:  "Rebooting...",
+			expectError: true,
+		},
+		{
+			description: "Context canceled",
+			ctx:         canceled,
+			rebootPage:  "Rebooting... Completed!",
+			downPolls:   2,
+			expectError: true,
+		},
+		{
+			description: "Router never comes back",
+			ctx:         context.Background(),
+			rebootPage:  "Rebooting... Completed!",
+			downPolls:   1 << 30,
+			expectError: true,
+		},
+		{
+			description:   "Router comes back",
+			ctx:           context.Background(),
+			rebootPage:    "Rebooting... Completed!",
+			downPolls:     2,
+			startingPolls: 2,
+		},
+	}
+
+	for _, tt := range testCases {
+		statusPolls := 0
+		client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+			switch r.URL.Path {
+			case "/userRpm/SysRebootRpm.htm":
+				return newPageResponse(r, 200, tt.rebootPage), nil
+			case "/" + statusPage:
+				if statusPolls++; statusPolls <= tt.downPolls {
+					return nil, fmt.Errorf("connection refused")
+				}
+				return newPageResponse(r, 200, validStatusPage), nil
+			case "/" + wiredConnectionsPage:
+				if statusPolls <= tt.downPolls+tt.startingPolls {
+					return newPageResponse(r, 503, ""), nil
+				}
+				return newPageResponse(r, 200, `{"data":[]}`), nil
+			}
+			return newPageResponse(r, 404, ""), nil
+		})
+		_, err := client.RebootAndWait(tt.ctx, 50*time.Millisecond)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.RebootAndWait() did not return an expected error", tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.RebootAndWait() returned an unexpected error: %v", tt.description, client, err)
+			}
+			if want := tt.downPolls + tt.startingPolls + 1; statusPolls != want {
+				t.Fatalf("FAIL: %s\n\t%v.RebootAndWait() polled the router %d times, want %d",
+					tt.description, client, statusPolls, want)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
@@ -1,75 +1,139 @@
 // Package archerc9v1 provides client logic for interacting with the TP Link
 // Archer C9 V1 wifi router.
+//
+// Each Client method that talks to the router has a variant with a Context
+// suffix, e.g. GetStatusContext, whose requests are canceled when the given
+// context is done. The methods without the suffix use context.Background.
 package archerc9v1
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
 	"fmt"
 	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
 	"log"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strings"
+	"time"
 )
 
-type logger interface {
-	Printf(string, ...interface{})
-}
-
 // Client manages communication with a TP Link Archer
 // C9 V1 wifi router.
 type Client struct {
 	userName, password, encodedBasicAuth string
 	baseURL                              *url.URL
 	httpClient                           *http.Client
-	logger                               logger
+	logger                               Logger
+	retry                                *RetryPolicy
+	userAgent                            string
 }
 
 // New returns a Client to an Archer C9 V1 wifi router given a user name, password,
 // url, http.Client, and a type implementing the logger interface. If any of the user
 // name, password, url, or http.Client arguments are invalid, then an error is returned.
-// If the lgr argument is nil, then a default logger of type *log.Logger is created
-// for the Client which logs to os.Stderr.
+// The lgr argument is adapted with PrintfLogger, so it gets the messages of every
+// level. If it is nil, the Client gets the default Logger of NewClient. New is kept
+// for compatibility, new code should use NewClient.
 func New(userName, password, rawURL string, httpClient *http.Client, lgr logger) (*Client, error) {
-	if len(userName) == 0 {
+	opts := []Option{WithCredentials(userName, password), WithHTTPClient(httpClient)}
+	if lgr != nil {
+		opts = append(opts, WithLogger(PrintfLogger(lgr)))
+	}
+	return NewClient(rawURL, opts...)
+}
+
+// NewClient returns a Client to the Archer C9 V1 wifi router at rawURL,
+// configured with opts. The WithCredentials option is required. An error is
+// returned if the url or any of the options are invalid.
+func NewClient(rawURL string, opts ...Option) (*Client, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if len(o.userName) == 0 {
 		return nil, errors.New("got empty value for userName (want a valid user name)")
 	}
-	if len(password) == 0 {
+	if len(o.password) == 0 {
 		return nil, errors.New("got empty value for password (want a non-empty password)")
 	}
-	// TODO: Check for "/" suffix in rawUrl and append it if not found
 	u, err := url.Parse(rawURL)
 	if err != nil {
 		return nil, errors.Wrap(err, "got error parsing rawUrl")
 	}
-	if !strings.HasPrefix(u.Scheme, "http") && !strings.HasPrefix(u.Scheme, "https") {
+	if u.Scheme != "http" && u.Scheme != "https" {
 		return nil, errors.New("got invalid scheme in rawUrl (want http or https): " + rawURL)
 	}
 	if len(u.Host) == 0 {
 		return nil, errors.New("got empty hostname in rawUrl (want http://hostname or https://hostname): " + rawURL)
 	}
+	// Relative URLs are resolved against the base URL, which drops its last
+	// path element unless the path ends in a slash.
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+		if u.RawPath != "" {
+			u.RawPath += "/"
+		}
+	}
+	if o.timeout < 0 {
+		return nil, errors.New("got negative timeout (want 0 for no limit or more)")
+	}
+
+	httpClient := o.httpClient
 	if httpClient == nil {
 		httpClient = &http.Client{}
 	}
+	if o.timeout > 0 || o.insecureTLS {
+		hc := *httpClient
+		httpClient = &hc
+	}
+	if o.timeout > 0 {
+		httpClient.Timeout = o.timeout
+	}
+	if o.insecureTLS {
+		t := insecureTransport(httpClient.Transport)
+		if t == nil {
+			return nil, fmt.Errorf("got http.Client with transport of type %T (want an *http.Transport to skip TLS verification)",
+				httpClient.Transport)
+		}
+		httpClient.Transport = t
+	}
+	lgr := o.logger
 	if lgr == nil {
-		lgr = log.New(os.Stderr, "INFO: ", log.Ldate|log.Ltime|log.Lshortfile)
+		lgr = NewStdLogger(log.New(os.Stderr, "", log.LstdFlags), LevelWarn, TextFormat)
 	}
-	return &Client{
-		userName:         userName,
-		password:         password,
+
+	c := &Client{
+		userName:         o.userName,
+		password:         o.password,
 		baseURL:          u,
-		encodedBasicAuth: base64.StdEncoding.EncodeToString([]byte(userName + ":" + password)),
+		encodedBasicAuth: base64.StdEncoding.EncodeToString([]byte(o.userName + ":" + o.password)),
 		httpClient:       httpClient,
 		logger:           lgr,
-	}, nil
+		userAgent:        o.userAgent,
+	}
+	c.SetRetryPolicy(o.retry)
+	return c, nil
 }
 
-// NewRequest creates an API request. A relative URL can be provided in urlStr, and if so,
-// it should always be specified without a preceding slash. The items specified in body will
-// be encoded as request body parameters.
+// NewRequest wraps NewRequestWithContext using context.Background.
 func (c *Client) NewRequest(method, urlStr string, body map[string]string) (*http.Request, error) {
-	u, err := c.baseURL.Parse(urlStr)
+	return c.NewRequestWithContext(context.Background(), method, urlStr, body)
+}
+
+// NewRequestWithContext creates an API request that is canceled when ctx is done. A
+// relative URL can be provided in urlStr, and if so, it is resolved relative to the
+// Client's base URL whether or not it has a preceding slash. The items specified in
+// body will be encoded as request body parameters.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, urlStr string,
+	body map[string]string) (*http.Request, error) {
+	u, err := c.resolve(urlStr)
 	if err != nil {
 		return nil, errors.Wrap(err, "got error creating new request URL")
 	}
@@ -79,28 +143,151 @@ func (c *Client) NewRequest(method, urlStr string, body map[string]string) (*htt
 		data.Set(k, v)
 	}
 
-	req, err := http.NewRequest(method, u.String(), strings.NewReader(data.Encode()))
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), strings.NewReader(data.Encode()))
 	if err != nil {
 		return nil, errors.Wrap(err, "got error creating new "+method+" request to: "+u.String())
 	}
 
+	c.setHeaders(req)
+	return req, nil
+}
+
+// NewUploadRequest wraps NewUploadRequestWithContext using context.Background.
+func (c *Client) NewUploadRequest(urlStr, fieldName, fileName string, r io.Reader,
+	fields map[string]string) (*http.Request, error) {
+	return c.NewUploadRequestWithContext(context.Background(), urlStr, fieldName, fileName, r, fields)
+}
+
+// NewUploadRequestWithContext creates a POST request that is canceled when ctx is
+// done and uploads the content read from r as a multipart/form-data file named
+// fileName in the form field fieldName. The items specified in fields are added to
+// the form as plain fields. A relative URL can be provided in urlStr as with
+// NewRequestWithContext.
+func (c *Client) NewUploadRequestWithContext(ctx context.Context, urlStr, fieldName, fileName string,
+	r io.Reader, fields map[string]string) (*http.Request, error) {
+	u, err := c.resolve(urlStr)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error creating new upload request URL")
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+	for k, v := range fields {
+		if err = w.WriteField(k, v); err != nil {
+			return nil, errors.Wrap(err, "got error writing form field "+k)
+		}
+	}
+	part, err := w.CreateFormFile(fieldName, fileName)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error creating form file "+fieldName)
+	}
+	if _, err = io.Copy(part, r); err != nil {
+		return nil, errors.Wrap(err, "got error reading content of "+fileName)
+	}
+	if err = w.Close(); err != nil {
+		return nil, errors.Wrap(err, "got error finishing multipart body")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u.String(), &body)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error creating new POST request to: "+u.String())
+	}
+
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	c.setHeaders(req)
+	return req, nil
+}
+
+// redactedValue replaces the values of secret query parameters in redactURL.
+const redactedValue = "REDACTED"
+
+// secretParams matches the names of the query parameters through which the
+// router's forms take passwords and passphrases, e.g. pskSecret and psw. Since
+// the router changes settings through GET requests, they end up in the URL.
+var secretParams = regexp.MustCompile(`(?i)psw|pwd|pass|secret|key`)
+
+// redactURL returns u for use in logs and error messages, with the values of
+// secret query parameters replaced and without user information.
+func redactURL(u *url.URL) string {
+	r := *u
+	r.User = nil
+	if q := r.Query(); len(q) > 0 {
+		for k := range q {
+			if secretParams.MatchString(k) {
+				q.Set(k, redactedValue)
+			}
+		}
+		r.RawQuery = q.Encode()
+	}
+	return r.String()
+}
+
+// resolve returns the URL of the page at urlStr, which is relative to the base
+// URL even if it starts with a slash, unless it is an absolute URL.
+func (c *Client) resolve(urlStr string) (*url.URL, error) {
+	return c.baseURL.Parse(strings.TrimPrefix(urlStr, "/"))
+}
+
+// setHeaders sets the headers the router expects on every request to it.
+func (c *Client) setHeaders(req *http.Request) {
 	req.Header.Set("Referer", c.baseURL.String())
 	req.Header.Set("Cookie", "Authorization=Basic "+c.encodedBasicAuth)
-	return req, nil
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
 }
 
-// Do sends an API request and returns the API response.
+// Do sends an API request and returns the API response. If c has a retry policy
+// and one of c's methods marked the request as only reading from the router, the
+// request is sent again as long as it fails in a way the policy considers
+// transient.
 func (c *Client) Do(req *http.Request) (*http.Response, error) {
-	return c.httpClient.Do(req)
+	if c.retry == nil || !isRead(req) {
+		return c.send(req)
+	}
+	return c.retry.do(c, req)
+}
+
+// send sends req with c's HTTP client and logs the outcome at the debug level.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ue, ok := err.(*url.Error); ok {
+			ue.URL = redactURL(req.URL)
+		}
+		c.logger.Debug("request failed", "method", req.Method, "url", redactURL(req.URL),
+			"duration", time.Since(start), "error", err)
+		return resp, err
+	}
+	c.logger.Debug("got response", "method", req.Method, "url", redactURL(req.URL), "status", resp.StatusCode,
+		"duration", time.Since(start))
+	return resp, nil
 }
 
 // CheckResponse checks the response for errors and returns them if present.
 // A response is considered an error if it has a status code outside the 200 range.
+// The error's cause is ErrUnauthorized for status code 401 and ErrRouterBusy for
+// status codes 429 and 503. Otherwise, the error is an *UnexpectedResponseError
+// holding the start of the response body.
 func CheckResponse(r *http.Response) error {
 	if r.StatusCode/100 == 2 {
 		return nil
 	}
 
-	return fmt.Errorf("got status code %d (want 200-299) when doing %s request to %s",
-		r.StatusCode, r.Request.Method, r.Request.URL.String())
+	reason := fmt.Sprintf("got status code %d (want 200-299)", r.StatusCode)
+	if r.Request != nil {
+		reason += fmt.Sprintf(" when doing %s request to %s", r.Request.Method, redactURL(r.Request.URL))
+	}
+	switch r.StatusCode {
+	case http.StatusUnauthorized:
+		return errors.Wrap(ErrUnauthorized, reason)
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return errors.Wrap(ErrRouterBusy, reason)
+	}
+	var data []byte
+	if r.Body != nil {
+		data, _ = ioutil.ReadAll(io.LimitReader(r.Body, maxBodySnippet+1))
+	}
+	return newUnexpectedResponseError(r, data, reason)
 }
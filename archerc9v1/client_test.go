@@ -1,6 +1,7 @@
 package archerc9v1 
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -23,6 +24,7 @@ var (
 	validRequestBody = map[string]string{"operation": "read"}
 	client           *Client
 	defaultLogger    = log.New(os.Stderr, "INFO: ", log.Ldate|log.Ltime|log.Lshortfile)
+	testLogger       = PrintfLogger(defaultLogger)
 )
 
 func TestNew(t *testing.T) {
@@ -99,7 +101,7 @@ func TestNew(t *testing.T) {
 				baseURL:          validURL,
 				encodedBasicAuth: validEncodedAuth,
 				httpClient:       &http.Client{},
-				logger:           defaultLogger,
+				logger:           testLogger,
 			},
 			expectError: false,
 		},
@@ -183,6 +185,119 @@ func TestNewRequest_withInvalidParameters(t *testing.T) {
 	}
 }
 
+func TestNewRequest_baseURLPath(t *testing.T) {
+	testCases := []*struct {
+		description, baseURL, relativeURL, expected string
+	}{
+		{
+			description: "Base URL without path",
+			baseURL:     "http://my-tplink-rtr",
+			relativeURL: "userRpm/StatusRpm.htm",
+			expected:    "http://my-tplink-rtr/userRpm/StatusRpm.htm",
+		},
+		{
+			description: "Base path without trailing slash",
+			baseURL:     "http://my-tplink-rtr/router",
+			relativeURL: "userRpm/StatusRpm.htm",
+			expected:    "http://my-tplink-rtr/router/userRpm/StatusRpm.htm",
+		},
+		{
+			description: "Base path with trailing slash and relative URL with preceding slash",
+			baseURL:     "http://my-tplink-rtr/router/",
+			relativeURL: "/userRpm/StatusRpm.htm",
+			expected:    "http://my-tplink-rtr/router/userRpm/StatusRpm.htm",
+		},
+		{
+			description: "Absolute URL",
+			baseURL:     "http://my-tplink-rtr/router",
+			relativeURL: "http://other-rtr/userRpm/StatusRpm.htm",
+			expected:    "http://other-rtr/userRpm/StatusRpm.htm",
+		},
+	}
+	for _, tt := range testCases {
+		client, _ = New(user, password, tt.baseURL, nil, nil)
+		req, err := client.NewRequest("GET", tt.relativeURL, nil)
+		if err != nil {
+			t.Fatalf("FAIL: %s\n\tNewRequest(GET, %s, nil) returned an unexpected error: %v",
+				tt.description, tt.relativeURL, err)
+		}
+		if req.URL.String() != tt.expected {
+			t.Fatalf("FAIL: %s\n\tNewRequest(GET, %s, nil) expected URL %s, got %s",
+				tt.description, tt.relativeURL, tt.expected, req.URL)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestNewRequestWithContext(t *testing.T) {
+	client, _ = New(user, password, validRawURL, nil, nil)
+	testDescription := "Request canceled with its context"
+	ctx, cancel := context.WithCancel(context.Background())
+	req, err := client.NewRequestWithContext(ctx, "GET", "foo", nil)
+	if err != nil {
+		t.Fatalf("FAIL: %s\n\tNewRequestWithContext() returned an unexpected error: %v", testDescription, err)
+	}
+	if req.Context() != ctx {
+		t.Fatalf("FAIL: %s\n\tNewRequestWithContext() returned a request without the given context", testDescription)
+	}
+
+	cancel()
+	client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		if err := r.Context().Err(); err != nil {
+			return nil, err
+		}
+		return newPageResponse(r, 200, validStatusPage), nil
+	})
+	if _, err = client.GetStatusContext(ctx); err == nil {
+		t.Fatalf("FAIL: %s\n\tGetStatusContext() with a canceled context did not return an expected error", testDescription)
+	}
+	if _, err = client.GetStatus(); err != nil {
+		t.Fatalf("FAIL: %s\n\tGetStatus() returned an unexpected error: %v", testDescription, err)
+	}
+	t.Logf("PASS: %s", testDescription)
+}
+
+func TestNewUploadRequest(t *testing.T) {
+	client, _ = New(user, password, validRawURL, nil, nil)
+	testDescription := "Valid upload request"
+	content := "binary\x00content"
+	failureMsgPrefix := fmt.Sprintf("FAIL: %s\n\tNewUploadRequest(%s, %s, %s, %q, %v)",
+		testDescription, "upload", "file", "config.bin", content, validRequestBody)
+	req, err := client.NewUploadRequest("upload", "file", "config.bin", strings.NewReader(content), validRequestBody)
+	if err != nil {
+		t.Fatalf("%s returned an unexpected error: %s", failureMsgPrefix, err.Error())
+	}
+	if req.Method != "POST" || req.URL.String() != client.baseURL.String()+"upload" {
+		t.Fatalf("%s returned request %s %s (want POST %supload)", failureMsgPrefix, req.Method, req.URL, client.baseURL)
+	}
+	if req.Header.Get("Cookie") != "Authorization=Basic "+validEncodedAuth {
+		t.Fatalf("%s does not contain expected Cookie header", failureMsgPrefix)
+	}
+	if err = req.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatalf("%s returned a request without a valid multipart body: %v", failureMsgPrefix, err)
+	}
+	if got := req.FormValue("operation"); got != "read" {
+		t.Fatalf("%s has form field operation=%q (want read)", failureMsgPrefix, got)
+	}
+	f, header, err := req.FormFile("file")
+	if err != nil {
+		t.Fatalf("%s has no file in form field file: %v", failureMsgPrefix, err)
+	}
+	defer f.Close()
+	data, _ := ioutil.ReadAll(f)
+	if header.Filename != "config.bin" || string(data) != content {
+		t.Fatalf("%s uploads file %s with content %q (want config.bin with %q)",
+			failureMsgPrefix, header.Filename, data, content)
+	}
+
+	_, err = client.NewUploadRequest(":foo", "file", "config.bin", strings.NewReader(content), nil)
+	if err == nil {
+		t.Fatalf("FAIL: Invalid relative URL\n\tNewUploadRequest(:foo, ...) expected an error")
+	}
+
+	t.Logf("PASS: %s", testDescription)
+}
+
 type RoundTripFunc func(r *http.Request) (*http.Response, error)
 
 func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -0,0 +1,55 @@
+package archerc9v1
+
+import (
+	"bytes"
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+)
+
+const (
+	configBackupPage  = "userRpm/config.bin"
+	configRestorePage = "incoming/RouterBakCfgUpload.cfg"
+)
+
+// BackupConfig wraps BackupConfigContext using context.Background.
+func (c *Client) BackupConfig(w io.Writer) error {
+	return c.BackupConfigContext(context.Background(), w)
+}
+
+// BackupConfigContext downloads the router's configuration file (config.bin) and
+// writes it to w or returns an error otherwise. The file is in the router's own
+// binary format and is meant to be restored with RestoreConfig.
+func (c *Client) BackupConfigContext(ctx context.Context, w io.Writer) error {
+	data, err := c.readPage(ctx, configBackupPage, nil, "back up configuration")
+	if err != nil {
+		return err
+	}
+	if _, err = w.Write(data); err != nil {
+		return errors.Wrap(err, "got error writing configuration backup")
+	}
+	return nil
+}
+
+// RestoreConfig wraps RestoreConfigContext using context.Background.
+func (c *Client) RestoreConfig(r io.Reader) error {
+	return c.RestoreConfigContext(context.Background(), r)
+}
+
+// RestoreConfigContext uploads the configuration file read from r, as written by
+// BackupConfig, through the router's restore form or returns an error otherwise.
+// The router reboots to apply the restored configuration, see WaitForRestart.
+func (c *Client) RestoreConfigContext(ctx context.Context, r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.Wrap(err, "got error reading configuration file")
+	}
+	if len(data) == 0 {
+		return errors.New("got empty configuration file (want a config.bin backup of the router)")
+	}
+
+	_, err = c.uploadPage(ctx, configRestorePage, "filename", "config.bin", bytes.NewReader(data), nil,
+		"restore configuration")
+	return err
+}
@@ -0,0 +1,105 @@
+package archerc9v1
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_BackupConfig(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       RoundTripFunc
+		expected    string
+		expectError bool
+	}{
+		{
+			description: "Missing backup page",
+			input:       servePages(nil),
+			expectError: true,
+		},
+		{
+			description: "Login page instead of backup",
+			input:       servePages(map[string]string{"/" + configBackupPage: "<TITLE>TP-LINK Archer C9</TITLE>"}),
+			expectError: true,
+		},
+		{
+			description: "Valid backup",
+			input:       servePages(map[string]string{"/" + configBackupPage: "\x01\x02config\x00"}),
+			expected:    "\x01\x02config\x00",
+		},
+	}
+
+	for _, tt := range testCases {
+		client = newPageTestClient(tt.input)
+		var buf bytes.Buffer
+		err := client.BackupConfig(&buf)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.BackupConfig() did not return an expected error", tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.BackupConfig() returned an unexpected error: %v", tt.description, client, err)
+			}
+			if buf.String() != tt.expected {
+				t.Fatalf("FAIL: %s\n\t%v.BackupConfig() wrote %q, want %q", tt.description, client, buf.String(), tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_RestoreConfig(t *testing.T) {
+	var uploaded string
+	client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.Method != "POST" || r.URL.Path != "/"+configRestorePage {
+			return newPageResponse(r, 404, ""), nil
+		}
+		f, _, err := r.FormFile("filename")
+		if err != nil {
+			return newPageResponse(r, 400, ""), nil
+		}
+		defer f.Close()
+		data, _ := ioutil.ReadAll(f)
+		uploaded = string(data)
+		return newPageResponse(r, 200, "Restoring..."), nil
+	})
+
+	testCases := []*struct {
+		description string
+		input       string
+		expectError bool
+	}{
+		{
+			description: "Empty configuration file",
+			input:       "",
+			expectError: true,
+		},
+		{
+			description: "Valid configuration file",
+			input:       "\x01\x02config\x00",
+		},
+	}
+
+	for _, tt := range testCases {
+		uploaded = ""
+		err := client.RestoreConfig(strings.NewReader(tt.input))
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.RestoreConfig(%q) did not return an expected error", tt.description, client, tt.input)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.RestoreConfig(%q) returned an unexpected error: %v",
+					tt.description, client, tt.input, err)
+			}
+			if uploaded != tt.input {
+				t.Fatalf("FAIL: %s\n\t%v.RestoreConfig(%q) uploaded %q", tt.description, client, tt.input, uploaded)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
@@ -1,6 +1,7 @@
 package archerc9v1 
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/pkg/errors"
@@ -11,6 +12,11 @@ import (
 
 const loginPageIndicator = "<title>tp-link archer c9"
 
+const (
+	wiredConnectionsPage    = "data/map_access_wire_client_grid.json"
+	wirelessConnectionsPage = "data/map_access_wireless_client_grid.json"
+)
+
 // Represents a node that is connected to the router.
 type Connection struct {
 	MacAddress string `json:"mac_addr"`
@@ -31,16 +37,20 @@ type connectionsResponse struct {
 	transport string
 }
 
-// GetWiredConnections returns a slice of Connections representing wired
-// connections to the router or returns an error otherwise.
+// GetWiredConnections wraps GetWiredConnectionsContext using context.Background.
 func (c *Client) GetWiredConnections() ([]*Connection, error) {
-	req, err := c.NewRequest("POST", "data/map_access_wire_client_grid.json", nil)
+	return c.GetWiredConnectionsContext(context.Background())
+}
+
+// GetWiredConnectionsContext returns a slice of Connections representing wired
+// connections to the router or returns an error otherwise.
+func (c *Client) GetWiredConnectionsContext(ctx context.Context) ([]*Connection, error) {
+	req, err := c.NewRequestWithContext(asRead(ctx), "POST", wiredConnectionsPage, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "got error creating request to get wired connections")
 	}
 
-	c.logger.Printf("sending request to get wired connections as (%s %s) ...",
-		req.Method, req.URL)
+	c.logger.Debug("sending request", "action", "get wired connections", "method", req.Method, "url", redactURL(req.URL))
 	resp, err := c.Do(req)
 	if resp != nil {
 		defer resp.Body.Close()
@@ -56,16 +66,20 @@ func (c *Client) GetWiredConnections() ([]*Connection, error) {
 	return connectionsResponse.getConnections()
 }
 
-// GetWirelessConnections returns a slice of Connection representing wireless
-// connections to the router or returns an error otherwise.
+// GetWirelessConnections wraps GetWirelessConnectionsContext using context.Background.
 func (c *Client) GetWirelessConnections() ([]*Connection, error) {
-	req, err := c.NewRequest("POST", "data/map_access_wireless_client_grid.json", nil)
+	return c.GetWirelessConnectionsContext(context.Background())
+}
+
+// GetWirelessConnectionsContext returns a slice of Connection representing wireless
+// connections to the router or returns an error otherwise.
+func (c *Client) GetWirelessConnectionsContext(ctx context.Context) ([]*Connection, error) {
+	req, err := c.NewRequestWithContext(asRead(ctx), "POST", wirelessConnectionsPage, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "got error creating request to get wireless connections")
 	}
 
-	c.logger.Printf("sending request to get wireless connections as (%s %s) ...",
-		req.Method, req.URL)
+	c.logger.Debug("sending request", "action", "get wireless connections", "method", req.Method, "url", redactURL(req.URL))
 	resp, err := c.Do(req)
 	if resp != nil {
 		defer resp.Body.Close()
@@ -90,21 +104,57 @@ func (r *connectionsResponse) getConnections() ([]*Connection, error) {
 	}
 
 	if len(data) == 0 {
-		return nil, fmt.Errorf("got empty body in %s connections response", r.transport)
+		return nil, newUnexpectedResponseError(r.response, nil,
+			fmt.Sprintf("got empty body in %s connections response", r.transport))
 	}
 
-	if strings.Contains(strings.ToLower(string(data)), loginPageIndicator) {
-		return nil,
-			fmt.Errorf("got the TP Link Archer C9 login webpage as %s connections response (want JSON), check your login credentials",
-				r.transport)
+	if err = checkBody(data); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("got error in %s connections response (want JSON)", r.transport))
 	}
 
 	c := new(connections)
 	if err = json.Unmarshal(data, c); err != nil {
-		return nil, errors.Wrap(err,
-			fmt.Sprintf("got error trying to decode %s connections response into JSON (tried to decode %s)",
-				r.transport, string(data)))
+		return nil, newUnexpectedResponseError(r.response, data,
+			fmt.Sprintf("got error trying to decode %s connections response into JSON: %v", r.transport, err))
 	}
 
 	return c.Data, nil
 }
+
+// FindConnection wraps FindConnectionContext using context.Background.
+func (c *Client) FindConnection(id string) (*Connection, error) {
+	return c.FindConnectionContext(context.Background(), id)
+}
+
+// FindConnectionContext returns the wired or wireless connection whose MAC address,
+// IP address or host name matches id. MAC addresses may be in any format accepted
+// by NormalizeMAC and host names are matched case-insensitively. An error is
+// returned if no connection or more than one node matches.
+func (c *Client) FindConnectionContext(ctx context.Context, id string) (*Connection, error) {
+	wired, err := c.GetWiredConnectionsContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error getting wired connections to find "+id)
+	}
+	wireless, err := c.GetWirelessConnectionsContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error getting wireless connections to find "+id)
+	}
+
+	mac, _ := NormalizeMAC(id)
+	var found *Connection
+	for _, conn := range append(wired, wireless...) {
+		m, _ := NormalizeMAC(conn.MacAddress)
+		if (mac == "" || m != mac) && conn.IPAddress != id && !strings.EqualFold(conn.Name, id) {
+			continue
+		}
+		if found != nil && m != found.MacAddress {
+			return nil, fmt.Errorf("got %q matching both %s and %s (want an identifier matching one node)",
+				id, found.MacAddress, m)
+		}
+		found = &Connection{MacAddress: m, IPAddress: conn.IPAddress, Name: conn.Name}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("got %q matching no connected node (want a MAC address, IP address or host name of a connected node)", id)
+	}
+	return found, nil
+}
@@ -165,7 +165,7 @@ func TestClient_GetWiredConnections(t *testing.T) {
 			encodedBasicAuth: validEncodedAuth,
 			baseURL:          validURL,
 			httpClient:       NewTestClient(tt.input),
-			logger:           defaultLogger,
+			logger:           testLogger,
 		}
 		got, err := client.GetWiredConnections()
 		if tt.expectError {
@@ -195,7 +195,7 @@ func TestClient_GetWirelessConnections(t *testing.T) {
 			encodedBasicAuth: validEncodedAuth,
 			baseURL:          validURL,
 			httpClient:       NewTestClient(tt.input),
-			logger:           defaultLogger,
+			logger:           testLogger,
 		}
 		got, err := client.GetWirelessConnections()
 		if tt.expectError {
@@ -216,3 +216,48 @@ func TestClient_GetWirelessConnections(t *testing.T) {
 		t.Logf("PASS: %s", tt.description)
 	}
 }
+
+func TestClient_FindConnection(t *testing.T) {
+	client = newPageTestClient(servePages(map[string]string{
+		"/data/map_access_wire_client_grid.json": `{"data":[
+{"mac_addr":"12-34-56-AA-BB-CC","ip_addr":"192.168.0.100","name":"Printer"},
+{"mac_addr":"22-22-22-AA-AA-AA","ip_addr":"192.168.0.101","name":"twin"}]}`,
+		"/data/map_access_wireless_client_grid.json": `{"data":[
+{"mac_addr":"1A-1A-1A-AA-AA-AA","ip_addr":"192.168.0.102","name":"twin"},
+{"mac_addr":"22-22-22-AA-AA-AA","ip_addr":"192.168.0.101","name":"twin"}]}`,
+	}))
+	printer := &Connection{MacAddress: "12-34-56-AA-BB-CC", IPAddress: "192.168.0.100", Name: "Printer"}
+	testCases := []*struct {
+		description string
+		input       string
+		expected    *Connection
+		expectError bool
+	}{
+		{description: "MAC address", input: "12:34:56:aa:bb:cc", expected: printer},
+		{description: "Bare hex MAC address", input: "123456AABBCC", expected: printer},
+		{description: "IP address", input: "192.168.0.100", expected: printer},
+		{description: "Host name", input: "printer", expected: printer},
+		{description: "Unknown node", input: "192.168.0.200", expectError: true},
+		{description: "Host name of several nodes", input: "twin", expectError: true},
+	}
+
+	for _, tt := range testCases {
+		got, err := client.FindConnection(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.FindConnection(%s) did not return an expected error",
+					tt.description, client, tt.input)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.FindConnection(%s) returned an unexpected error: %v",
+					tt.description, client, tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.FindConnection(%s) returned %v, want %v",
+					tt.description, client, tt.input, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
@@ -0,0 +1,211 @@
+package archerc9v1
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"github.com/pkg/errors"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	dhcpSettingsPage = "userRpm/LanDhcpServerRpm.htm"
+	lanSettingsPage  = "userRpm/NetworkCfgRpm.htm"
+
+	// unsetIP is the value the router uses for an optional address that
+	// has not been configured.
+	unsetIP = "0.0.0.0"
+
+	minDHCPLeaseTime = time.Minute
+	maxDHCPLeaseTime = 2880 * time.Minute
+)
+
+// DHCPSettings represents the configuration of the DHCP server on the router's
+// LAN. Optional addresses that are not configured on the router are empty.
+type DHCPSettings struct {
+	Enabled    bool          `json:"enabled"`
+	StartIP    string        `json:"start_ip"`
+	EndIP      string        `json:"end_ip"`
+	LeaseTime  time.Duration `json:"lease_time"`
+	Gateway    string        `json:"gateway"`
+	Domain     string        `json:"domain"`
+	DNSServers []string      `json:"dns_servers"`
+}
+
+// GetDHCPSettings wraps GetDHCPSettingsContext using context.Background.
+func (c *Client) GetDHCPSettings() (*DHCPSettings, error) {
+	return c.GetDHCPSettingsContext(context.Background())
+}
+
+// GetDHCPSettingsContext returns the router's current DHCP server settings or
+// returns an error otherwise.
+func (c *Client) GetDHCPSettingsContext(ctx context.Context) (*DHCPSettings, error) {
+	data, err := c.readPage(ctx, dhcpSettingsPage, nil, "get DHCP settings")
+	if err != nil {
+		return nil, err
+	}
+
+	para, err := parseJSArray(data, "DHCPPara")
+	if err != nil {
+		return nil, errors.Wrap(err, "got error parsing DHCP settings page")
+	}
+
+	enabled, err := para.boolAt(0)
+	if err != nil {
+		return nil, errors.Wrap(err, "got invalid DHCP server state")
+	}
+	lease, err := para.intAt(3)
+	if err != nil {
+		return nil, errors.Wrap(err, "got invalid DHCP lease time")
+	}
+
+	s := &DHCPSettings{
+		Enabled:   enabled,
+		StartIP:   para.strAt(1),
+		EndIP:     para.strAt(2),
+		LeaseTime: time.Duration(lease) * time.Minute,
+		Gateway:   para.strAt(4),
+		Domain:    para.strAt(5),
+	}
+	if s.Gateway == unsetIP {
+		s.Gateway = ""
+	}
+	for _, dns := range []string{para.strAt(6), para.strAt(7)} {
+		if dns != "" && dns != unsetIP {
+			s.DNSServers = append(s.DNSServers, dns)
+		}
+	}
+	return s, nil
+}
+
+// SetDHCPSettings wraps SetDHCPSettingsContext using context.Background.
+func (c *Client) SetDHCPSettings(s *DHCPSettings) error {
+	return c.SetDHCPSettingsContext(context.Background(), s)
+}
+
+// SetDHCPSettingsContext changes the router's DHCP server settings to s. The
+// settings are validated against the router's LAN subnet before they are submitted,
+// and an error is returned if they are invalid or cannot be applied.
+func (c *Client) SetDHCPSettingsContext(ctx context.Context, s *DHCPSettings) error {
+	if s == nil {
+		return errors.New("got nil DHCP settings (want non-nil settings)")
+	}
+
+	lan, err := c.getLANNetwork(ctx)
+	if err != nil {
+		return errors.Wrap(err, "got error getting LAN subnet to validate DHCP settings")
+	}
+	if err = s.Validate(lan); err != nil {
+		return errors.Wrap(err, "got invalid DHCP settings")
+	}
+
+	q := url.Values{}
+	q.Set("dhcpserver", boolParam(s.Enabled))
+	q.Set("ip1", s.StartIP)
+	q.Set("ip2", s.EndIP)
+	q.Set("Lease", strconv.Itoa(int(s.LeaseTime/time.Minute)))
+	q.Set("gateway", orUnsetIP(s.Gateway))
+	q.Set("domain", s.Domain)
+	dns := append(append([]string(nil), s.DNSServers...), "", "")
+	q.Set("dnsserver", orUnsetIP(dns[0]))
+	q.Set("dnsserver2", orUnsetIP(dns[1]))
+	q.Set("Save", "Save")
+
+	_, err = c.getPage(ctx, dhcpSettingsPage, q, "set DHCP settings")
+	return err
+}
+
+// Validate checks that s describes a usable DHCP configuration on the LAN
+// subnet lan. The address pool and gateway must lie inside lan and the lease
+// time must be a whole number of minutes that the router accepts.
+func (s *DHCPSettings) Validate(lan *net.IPNet) error {
+	start, err := parseIPv4(s.StartIP)
+	if err != nil {
+		return errors.Wrap(err, "got invalid start address")
+	}
+	end, err := parseIPv4(s.EndIP)
+	if err != nil {
+		return errors.Wrap(err, "got invalid end address")
+	}
+	if !lan.Contains(start) || !lan.Contains(end) {
+		return fmt.Errorf("got address pool %s-%s outside of the LAN subnet (want addresses in %s)",
+			s.StartIP, s.EndIP, lan)
+	}
+	if ipv4ToUint32(start) > ipv4ToUint32(end) {
+		return fmt.Errorf("got start address %s after end address %s (want start <= end)",
+			s.StartIP, s.EndIP)
+	}
+
+	if s.LeaseTime < minDHCPLeaseTime || s.LeaseTime > maxDHCPLeaseTime || s.LeaseTime%time.Minute != 0 {
+		return fmt.Errorf("got lease time %s (want whole minutes between %s and %s)",
+			s.LeaseTime, minDHCPLeaseTime, maxDHCPLeaseTime)
+	}
+
+	if s.Gateway != "" {
+		gw, err := parseIPv4(s.Gateway)
+		if err != nil {
+			return errors.Wrap(err, "got invalid default gateway")
+		}
+		if !lan.Contains(gw) {
+			return fmt.Errorf("got default gateway %s outside of the LAN subnet (want an address in %s)",
+				s.Gateway, lan)
+		}
+	}
+
+	if len(s.DNSServers) > 2 {
+		return fmt.Errorf("got %d DNS servers (want at most 2)", len(s.DNSServers))
+	}
+	for _, dns := range s.DNSServers {
+		if _, err := parseIPv4(dns); err != nil {
+			return errors.Wrap(err, "got invalid DNS server")
+		}
+	}
+	return nil
+}
+
+// getLANNetwork returns the subnet of the router's LAN interface.
+func (c *Client) getLANNetwork(ctx context.Context) (*net.IPNet, error) {
+	data, err := c.readPage(ctx, lanSettingsPage, nil, "get LAN settings")
+	if err != nil {
+		return nil, err
+	}
+
+	para, err := parseJSArray(data, "lanPara")
+	if err != nil {
+		return nil, errors.Wrap(err, "got error parsing LAN settings page")
+	}
+
+	ip, err := parseIPv4(para.strAt(1))
+	if err != nil {
+		return nil, errors.Wrap(err, "got invalid LAN IP address")
+	}
+	mask, err := parseIPv4(para.strAt(2))
+	if err != nil {
+		return nil, errors.Wrap(err, "got invalid LAN subnet mask")
+	}
+	m := net.IPMask(mask)
+	return &net.IPNet{IP: ip.Mask(m), Mask: m}, nil
+}
+
+// parseIPv4 parses s as a dotted decimal IPv4 address.
+func parseIPv4(s string) (net.IP, error) {
+	ip := net.ParseIP(s).To4()
+	if ip == nil {
+		return nil, fmt.Errorf("got %q (want a dotted decimal IPv4 address)", s)
+	}
+	return ip, nil
+}
+
+func ipv4ToUint32(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+func orUnsetIP(ip string) string {
+	if ip == "" {
+		return unsetIP
+	}
+	return ip
+}
@@ -0,0 +1,212 @@
+package archerc9v1
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	validDHCPPage = `<script type="text/javascript">
+var DHCPPara = new Array(
+1,
+"192.168.0.100",
+"192.168.0.199",
+120,
+"192.168.0.1",
+"home",
+"8.8.8.8",
+"0.0.0.0",
+0,0 );
+</script>`
+	validLANPage = `<script type="text/javascript">
+var lanPara = new Array(
+"00-0A-EB-13-7B-00",
+"192.168.0.1",
+"255.255.255.0",
+0,0 );
+</script>`
+	validDHCPSettings = &DHCPSettings{
+		Enabled:    true,
+		StartIP:    "192.168.0.100",
+		EndIP:      "192.168.0.199",
+		LeaseTime:  120 * time.Minute,
+		Gateway:    "192.168.0.1",
+		Domain:     "home",
+		DNSServers: []string{"8.8.8.8"},
+	}
+	_, validLANNetwork, _ = net.ParseCIDR("192.168.0.0/24")
+)
+
+func TestClient_GetDHCPSettings(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       RoundTripFunc
+		expected    *DHCPSettings
+		expectError bool
+	}{
+		{
+			description: "404 status code in response",
+			input:       servePages(nil),
+			expectError: true,
+		},
+		{
+			description: "Page without DHCP settings",
+			input:       servePages(map[string]string{"/" + dhcpSettingsPage: validLANPage}),
+			expectError: true,
+		},
+		{
+			description: "Invalid lease time",
+			input: servePages(map[string]string{"/" + dhcpSettingsPage: `
+var DHCPPara = new Array(1, "192.168.0.100", "192.168.0.199", "forever", 0,0 );`}),
+			expectError: true,
+		},
+		{
+			description: "Valid response",
+			input:       servePages(map[string]string{"/" + dhcpSettingsPage: validDHCPPage}),
+			expected:    validDHCPSettings,
+		},
+	}
+
+	for _, tt := range testCases {
+		client = newPageTestClient(tt.input)
+		got, err := client.GetDHCPSettings()
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetDHCPSettings() did not return an expected error",
+					tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetDHCPSettings() returned an unexpected error: %v",
+					tt.description, client, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.GetDHCPSettings() returned %+v, want %+v",
+					tt.description, client, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_SetDHCPSettings(t *testing.T) {
+	var saved url.Values
+	fn := func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/"+dhcpSettingsPage {
+			saved = r.URL.Query()
+		}
+		return servePages(map[string]string{
+			"/" + lanSettingsPage:  validLANPage,
+			"/" + dhcpSettingsPage: validDHCPPage,
+		})(r)
+	}
+
+	client = newPageTestClient(fn)
+	invalid := *validDHCPSettings
+	invalid.EndIP = "192.168.1.10"
+	if err := client.SetDHCPSettings(&invalid); err == nil {
+		t.Fatalf("FAIL: Pool outside of LAN\n\t%v.SetDHCPSettings(%+v) did not return an expected error",
+			client, invalid)
+	}
+	if saved != nil {
+		t.Fatalf("FAIL: Pool outside of LAN\n\t%v.SetDHCPSettings(%+v) submitted invalid settings: %v",
+			client, invalid, saved)
+	}
+	t.Logf("PASS: Pool outside of LAN")
+
+	if err := client.SetDHCPSettings(validDHCPSettings); err != nil {
+		t.Fatalf("FAIL: Valid settings\n\t%v.SetDHCPSettings(%+v) returned an unexpected error: %v",
+			client, validDHCPSettings, err)
+	}
+	expected := url.Values{
+		"dhcpserver": {"1"},
+		"ip1":        {"192.168.0.100"},
+		"ip2":        {"192.168.0.199"},
+		"Lease":      {"120"},
+		"gateway":    {"192.168.0.1"},
+		"domain":     {"home"},
+		"dnsserver":  {"8.8.8.8"},
+		"dnsserver2": {"0.0.0.0"},
+		"Save":       {"Save"},
+	}
+	if !reflect.DeepEqual(saved, expected) {
+		t.Fatalf("FAIL: Valid settings\n\t%v.SetDHCPSettings(%+v) submitted %v, want %v",
+			client, validDHCPSettings, saved, expected)
+	}
+	t.Logf("PASS: Valid settings")
+}
+
+func TestDHCPSettings_Validate(t *testing.T) {
+	testCases := []*struct {
+		description string
+		modify      func(s *DHCPSettings)
+		expectError bool
+	}{
+		{
+			description: "Valid settings",
+			modify:      func(s *DHCPSettings) {},
+		},
+		{
+			description: "No gateway or DNS servers",
+			modify: func(s *DHCPSettings) {
+				s.Gateway = ""
+				s.DNSServers = nil
+			},
+		},
+		{
+			description: "Invalid start address",
+			modify:      func(s *DHCPSettings) { s.StartIP = "192.168.0" },
+			expectError: true,
+		},
+		{
+			description: "Start address outside of LAN",
+			modify:      func(s *DHCPSettings) { s.StartIP = "10.0.0.100" },
+			expectError: true,
+		},
+		{
+			description: "Start address after end address",
+			modify:      func(s *DHCPSettings) { s.StartIP = "192.168.0.200" },
+			expectError: true,
+		},
+		{
+			description: "Lease time too long",
+			modify:      func(s *DHCPSettings) { s.LeaseTime = 49 * time.Hour },
+			expectError: true,
+		},
+		{
+			description: "Lease time not in whole minutes",
+			modify:      func(s *DHCPSettings) { s.LeaseTime = 90 * time.Second },
+			expectError: true,
+		},
+		{
+			description: "Gateway outside of LAN",
+			modify:      func(s *DHCPSettings) { s.Gateway = "10.0.0.1" },
+			expectError: true,
+		},
+		{
+			description: "Too many DNS servers",
+			modify:      func(s *DHCPSettings) { s.DNSServers = []string{"1.1.1.1", "8.8.8.8", "9.9.9.9"} },
+			expectError: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		s := *validDHCPSettings
+		tt.modify(&s)
+		err := s.Validate(validLANNetwork)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%+v.Validate(%s) did not return an expected error",
+					tt.description, s, validLANNetwork)
+			}
+		} else if err != nil {
+			t.Fatalf("FAIL: %s\n\t%+v.Validate(%s) returned an unexpected error: %v",
+				tt.description, s, validLANNetwork, err)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
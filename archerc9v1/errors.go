@@ -0,0 +1,85 @@
+package archerc9v1
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"net/http"
+	"strings"
+)
+
+// The errors below are the causes of the errors the Client returns when the
+// router rejects a request. Check for them with errors.Is, which also sees
+// through the context added with errors.Wrap.
+var (
+	// ErrUnauthorized means the router did not accept the user name and
+	// password and answered with its login page or status code 401.
+	ErrUnauthorized = errors.New("got the TP Link Archer C9 login webpage, check your login credentials")
+	// ErrSessionLocked means the router refused the request because another
+	// administrator is logged in to its web interface.
+	ErrSessionLocked = errors.New("got router locked by another administrator session (want it to log out first)")
+	// ErrRouterBusy means the router is too busy to handle the request right
+	// now and answered with status code 429 or 503.
+	ErrRouterBusy = errors.New("got router busy (want it to handle the request, try again later)")
+	// ErrUnexpectedResponse means the router answered with a response the
+	// Client could not make sense of. The error is an *UnexpectedResponseError,
+	// which errors.As retrieves for the details.
+	ErrUnexpectedResponse = errors.New("got unexpected response from the router")
+)
+
+// sessionLockedIndicator is shown by the router instead of the requested page
+// while another administrator is logged in.
+const sessionLockedIndicator = "you have no authority to access this router"
+
+// maxBodySnippet is the maximum number of bytes of the response body kept in
+// an UnexpectedResponseError.
+const maxBodySnippet = 256
+
+// UnexpectedResponseError is returned when the router answers a request with a
+// response the Client could not make sense of, e.g. a status code outside the
+// 200 range or an empty body.
+type UnexpectedResponseError struct {
+	// Endpoint is the URL path of the request.
+	Endpoint string
+	// StatusCode is the status code of the response.
+	StatusCode int
+	// Body is the start of the response body, if it was read.
+	Body string
+	// Reason tells what was wrong with the response.
+	Reason string
+}
+
+func newUnexpectedResponseError(resp *http.Response, data []byte, reason string) *UnexpectedResponseError {
+	e := &UnexpectedResponseError{StatusCode: resp.StatusCode, Body: string(data), Reason: reason}
+	if resp.Request != nil && resp.Request.URL != nil {
+		e.Endpoint = resp.Request.URL.Path
+	}
+	if len(e.Body) > maxBodySnippet {
+		e.Body = e.Body[:maxBodySnippet] + "..."
+	}
+	return e
+}
+
+func (e *UnexpectedResponseError) Error() string {
+	if e.Body == "" {
+		return e.Reason
+	}
+	return fmt.Sprintf("%s (body: %q)", e.Reason, e.Body)
+}
+
+// Is reports whether target is ErrUnexpectedResponse.
+func (e *UnexpectedResponseError) Is(target error) bool {
+	return target == ErrUnexpectedResponse
+}
+
+// checkBody returns ErrUnauthorized or ErrSessionLocked if data is the login page
+// or the page the router shows while another administrator is logged in.
+func checkBody(data []byte) error {
+	body := strings.ToLower(string(data))
+	if strings.Contains(body, loginPageIndicator) {
+		return ErrUnauthorized
+	}
+	if strings.Contains(body, sessionLockedIndicator) {
+		return ErrSessionLocked
+	}
+	return nil
+}
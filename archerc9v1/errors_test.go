@@ -0,0 +1,123 @@
+package archerc9v1
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestErrors(t *testing.T) {
+	longBody := strings.Repeat("x", 2*maxBodySnippet)
+	testCases := []*struct {
+		description      string
+		input            RoundTripFunc
+		call             func() error
+		expected         error
+		expectedEndpoint string
+		expectedBody     string
+	}{
+		{
+			description: "401 is unauthorized",
+			input: func(r *http.Request) (*http.Response, error) {
+				return newPageResponse(r, 401, ""), nil
+			},
+			call:     func() error { _, err := client.GetStatus(); return err },
+			expected: ErrUnauthorized,
+		},
+		{
+			description: "Login page is unauthorized",
+			input:       servePages(map[string]string{"/" + statusPage: "<TITLE>TP-LINK Archer C9</TITLE>"}),
+			call:        func() error { _, err := client.GetStatus(); return err },
+			expected:    ErrUnauthorized,
+		},
+		{
+			description: "Login page instead of connections is unauthorized",
+			input:       servePages(map[string]string{"/" + wiredConnectionsPage: "<title>TP-LINK Archer C9</title>"}),
+			call:        func() error { _, err := client.GetWiredConnections(); return err },
+			expected:    ErrUnauthorized,
+		},
+		{
+			description: "Page of another administrator session is session locked",
+			input: servePages(map[string]string{"/" + statusPage: "<html>You have no authority to access this router! " +
+				"Another administrator is logged in.</html>"}),
+			call:     func() error { _, err := client.GetStatus(); return err },
+			expected: ErrSessionLocked,
+		},
+		{
+			description: "503 is router busy",
+			input: func(r *http.Request) (*http.Response, error) {
+				return newPageResponse(r, 503, ""), nil
+			},
+			call:     func() error { _, err := client.GetWiredConnections(); return err },
+			expected: ErrRouterBusy,
+		},
+		{
+			description: "500 is an unexpected response",
+			input: func(r *http.Request) (*http.Response, error) {
+				return newPageResponse(r, 500, ""), nil
+			},
+			call:             func() error { _, err := client.GetStatus(); return err },
+			expected:         ErrUnexpectedResponse,
+			expectedEndpoint: "/" + statusPage,
+		},
+		{
+			description: "500 with a body is an unexpected response with a body snippet",
+			input: func(r *http.Request) (*http.Response, error) {
+				return newPageResponse(r, 500, longBody), nil
+			},
+			call:             func() error { _, err := client.GetWiredConnections(); return err },
+			expected:         ErrUnexpectedResponse,
+			expectedEndpoint: "/" + wiredConnectionsPage,
+			expectedBody:     longBody[:maxBodySnippet] + "...",
+		},
+		{
+			description:      "Missing page is an unexpected response",
+			input:            servePages(nil),
+			call:             func() error { _, err := client.ListPortForwards(); return err },
+			expected:         ErrUnexpectedResponse,
+			expectedEndpoint: "/" + portForwardsPage,
+		},
+		{
+			description:      "Empty page is an unexpected response",
+			input:            servePages(map[string]string{"/" + statusPage: ""}),
+			call:             func() error { _, err := client.GetStatus(); return err },
+			expected:         ErrUnexpectedResponse,
+			expectedEndpoint: "/" + statusPage,
+		},
+		{
+			description:      "Invalid JSON is an unexpected response with a body snippet",
+			input:            servePages(map[string]string{"/" + wirelessConnectionsPage: longBody}),
+			call:             func() error { _, err := client.GetWirelessConnections(); return err },
+			expected:         ErrUnexpectedResponse,
+			expectedEndpoint: "/" + wirelessConnectionsPage,
+			expectedBody:     longBody[:maxBodySnippet] + "...",
+		},
+		{
+			description:      "Invalid reboot response is an unexpected response",
+			input:            servePages(map[string]string{"/userRpm/SysRebootRpm.htm": "Not rebooting"}),
+			call:             func() error { return client.Reboot() },
+			expected:         ErrUnexpectedResponse,
+			expectedEndpoint: "/userRpm/SysRebootRpm.htm",
+			expectedBody:     "Not rebooting",
+		},
+	}
+
+	for _, tt := range testCases {
+		client = newPageTestClient(tt.input)
+		err := tt.call()
+		if !errors.Is(err, tt.expected) {
+			t.Fatalf("FAIL: %s\n\tgot error %v, want one that is %v", tt.description, err, tt.expected)
+		}
+		var unexpected *UnexpectedResponseError
+		if errors.As(err, &unexpected) != (tt.expected == ErrUnexpectedResponse) {
+			t.Fatalf("FAIL: %s\n\tgot error %v, want an *UnexpectedResponseError only for unexpected responses",
+				tt.description, err)
+		}
+		if unexpected != nil && (unexpected.Endpoint != tt.expectedEndpoint || unexpected.Body != tt.expectedBody) {
+			t.Fatalf("FAIL: %s\n\tgot unexpected response error for endpoint %q with body %q, want %q with body %q",
+				tt.description, unexpected.Endpoint, unexpected.Body, tt.expectedEndpoint, tt.expectedBody)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
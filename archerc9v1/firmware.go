@@ -0,0 +1,155 @@
+package archerc9v1
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+const firmwareUpgradePage = "incoming/Firmware.htm"
+
+// The firmware images start with TP Link's header, which names the vendor at the
+// offset below and usually holds the firmware version after it. The rest of the
+// header's layout differs between TP Link's firmware formats and is not checked,
+// so ParseFirmwareImage only catches files that are clearly not TP Link firmware.
+// The router checks the rest of the header itself before flashing an image.
+const (
+	firmwareVendorOffset  = 0x04
+	firmwareVendorSize    = 24
+	firmwareVersionOffset = 0x1c
+	firmwareVersionSize   = 36
+
+	firmwareVendor = "TP-LINK Technologies"
+
+	// MinFirmwareSize and MaxFirmwareSize bound the size of an Archer C9 V1
+	// firmware image.
+	MinFirmwareSize = 1 << 20
+	MaxFirmwareSize = 64 << 20
+)
+
+// FirmwareImage describes a firmware image as read from its header. Version is
+// informational: it is the string found where TP Link's headers usually keep the
+// firmware version and is empty or unparsable if the image keeps it elsewhere.
+type FirmwareImage struct {
+	Version string `json:"version"`
+	Size    int    `json:"size"`
+}
+
+// ParseFirmwareImage checks that data has the size of an Archer C9 V1 firmware
+// image and a TP Link header and returns its description or returns an error
+// otherwise.
+func ParseFirmwareImage(data []byte) (*FirmwareImage, error) {
+	if len(data) < MinFirmwareSize || len(data) > MaxFirmwareSize {
+		return nil, fmt.Errorf("got firmware image of %d bytes (want %d to %d bytes)",
+			len(data), MinFirmwareSize, MaxFirmwareSize)
+	}
+	vendor := headerString(data[firmwareVendorOffset : firmwareVendorOffset+firmwareVendorSize])
+	if vendor != firmwareVendor {
+		return nil, fmt.Errorf("got firmware image from vendor %q (want %q)", vendor, firmwareVendor)
+	}
+	return &FirmwareImage{
+		Version: headerString(data[firmwareVersionOffset : firmwareVersionOffset+firmwareVersionSize]),
+		Size:    len(data),
+	}, nil
+}
+
+// headerString returns the NUL padded string b of a firmware header.
+func headerString(b []byte) string {
+	if i := bytes.IndexByte(b, 0); i >= 0 {
+		b = b[:i]
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// FirmwareUpgrade wraps FirmwareUpgradeContext using context.Background.
+func (c *Client) FirmwareUpgrade(r io.Reader) (*FirmwareImage, error) {
+	return c.FirmwareUpgradeContext(context.Background(), r)
+}
+
+// FirmwareUpgradeContext checks the firmware image read from r with
+// ParseFirmwareImage and uploads it through the router's firmware upgrade form,
+// returning the image's description or an error otherwise. The router flashes the
+// image and reboots, which takes several minutes, see WaitForRestart. Comparing the
+// image's version with the router's is left to the caller, see
+// CompareFirmwareVersions.
+func (c *Client) FirmwareUpgradeContext(ctx context.Context, r io.Reader) (*FirmwareImage, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error reading firmware image")
+	}
+	img, err := ParseFirmwareImage(data)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = c.uploadPage(ctx, firmwareUpgradePage, "Filename", "firmware.bin", bytes.NewReader(data), nil,
+		"upgrade firmware"); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// CompareFirmwareVersions compares the firmware versions a and b, e.g.
+// "3.16.0 0.9.1 v6015.0 Build 160906 Rel.61584n", and returns -1, 0 or 1 if a
+// is older than, the same as or newer than b. Versions are ordered by their
+// leading release number and then by their build date if both have one. An
+// error is returned if either version does not start with a release number.
+func CompareFirmwareVersions(a, b string) (int, error) {
+	va, err := parseFirmwareVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := parseFirmwareVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	if len(va) != len(vb) {
+		// Only one version has a build date, so only compare the release numbers.
+		va, vb = va[:3], vb[:3]
+	}
+	for i := range va {
+		if va[i] != vb[i] {
+			if va[i] < vb[i] {
+				return -1, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// parseFirmwareVersion returns the major, minor and patch release numbers of
+// version followed by its build date, if any.
+func parseFirmwareVersion(version string) ([]int, error) {
+	fields := strings.Fields(version)
+	if len(fields) == 0 {
+		return nil, errors.New("got empty firmware version (want a version such as 3.16.0 Build 160906)")
+	}
+	release := strings.Split(fields[0], ".")
+	if len(release) > 3 {
+		return nil, fmt.Errorf("got firmware version %q (want a release number such as 3.16.0)", version)
+	}
+	v := make([]int, 3)
+	for i, s := range release {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return nil, fmt.Errorf("got firmware version %q (want a release number such as 3.16.0)", version)
+		}
+		v[i] = n
+	}
+	for i, f := range fields {
+		if f == "Build" && i+1 < len(fields) {
+			build, err := strconv.Atoi(fields[i+1])
+			if err != nil {
+				return nil, fmt.Errorf("got firmware version %q with invalid build date", version)
+			}
+			v = append(v, build)
+			break
+		}
+	}
+	return v, nil
+}
@@ -0,0 +1,192 @@
+package archerc9v1
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// newFirmwareImage returns a firmware image of size bytes whose header holds
+// the given vendor and version.
+func newFirmwareImage(size int, vendor, version string) []byte {
+	data := make([]byte, size)
+	copy(data[firmwareVendorOffset:], vendor)
+	copy(data[firmwareVersionOffset:], version)
+	return data
+}
+
+const validFirmwareVersion = "3.17.0 Build 170315 Rel.64310n"
+
+func TestParseFirmwareImage(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       []byte
+		expected    *FirmwareImage
+		expectError bool
+	}{
+		{
+			description: "Image too small",
+			input:       newFirmwareImage(MinFirmwareSize-1, firmwareVendor, validFirmwareVersion),
+			expectError: true,
+		},
+		{
+			description: "Image from other vendor",
+			input:       newFirmwareImage(MinFirmwareSize, "OpenWrt", validFirmwareVersion),
+			expectError: true,
+		},
+		{
+			description: "Image too large",
+			input:       newFirmwareImage(MaxFirmwareSize+1, firmwareVendor, validFirmwareVersion),
+			expectError: true,
+		},
+		{
+			description: "Valid image",
+			input:       newFirmwareImage(MinFirmwareSize, firmwareVendor, validFirmwareVersion),
+			expected: &FirmwareImage{
+				Version: validFirmwareVersion,
+				Size:    MinFirmwareSize,
+			},
+		},
+		{
+			description: "Valid image without version",
+			input:       newFirmwareImage(MinFirmwareSize, firmwareVendor, ""),
+			expected:    &FirmwareImage{Size: MinFirmwareSize},
+		},
+	}
+
+	for _, tt := range testCases {
+		got, err := ParseFirmwareImage(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tParseFirmwareImage() did not return an expected error", tt.description)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\tParseFirmwareImage() returned an unexpected error: %v", tt.description, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\tParseFirmwareImage() returned %+v, want %+v", tt.description, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_FirmwareUpgrade(t *testing.T) {
+	var uploaded []byte
+	client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.Method != "POST" || r.URL.Path != "/"+firmwareUpgradePage {
+			return newPageResponse(r, 404, ""), nil
+		}
+		f, _, err := r.FormFile("Filename")
+		if err != nil {
+			return newPageResponse(r, 400, ""), nil
+		}
+		defer f.Close()
+		uploaded, _ = ioutil.ReadAll(f)
+		return newPageResponse(r, 200, "Upgrading..."), nil
+	})
+
+	testCases := []*struct {
+		description string
+		input       []byte
+		expectError bool
+	}{
+		{
+			description: "Invalid image",
+			input:       []byte("not a firmware image"),
+			expectError: true,
+		},
+		{
+			description: "Valid image",
+			input:       newFirmwareImage(MinFirmwareSize, firmwareVendor, validFirmwareVersion),
+		},
+	}
+
+	for _, tt := range testCases {
+		uploaded = nil
+		img, err := client.FirmwareUpgrade(bytes.NewReader(tt.input))
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.FirmwareUpgrade() did not return an expected error", tt.description, client)
+			}
+			if uploaded != nil {
+				t.Fatalf("FAIL: %s\n\t%v.FirmwareUpgrade() uploaded an invalid image", tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.FirmwareUpgrade() returned an unexpected error: %v", tt.description, client, err)
+			}
+			if !bytes.Equal(uploaded, tt.input) {
+				t.Fatalf("FAIL: %s\n\t%v.FirmwareUpgrade() uploaded %d bytes, want %d", tt.description, client,
+					len(uploaded), len(tt.input))
+			}
+			if img.Version != validFirmwareVersion {
+				t.Fatalf("FAIL: %s\n\t%v.FirmwareUpgrade() returned version %q, want %q", tt.description, client,
+					img.Version, validFirmwareVersion)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestCompareFirmwareVersions(t *testing.T) {
+	testCases := []*struct {
+		description string
+		a, b        string
+		expected    int
+		expectError bool
+	}{
+		{
+			description: "Invalid version",
+			a:           "v6015.0 Build 160906",
+			b:           validFirmwareVersion,
+			expectError: true,
+		},
+		{
+			description: "Older release",
+			a:           "3.16.0 0.9.1 v6015.0 Build 160906 Rel.61584n",
+			b:           validFirmwareVersion,
+			expected:    -1,
+		},
+		{
+			description: "Same version",
+			a:           validFirmwareVersion,
+			b:           validFirmwareVersion,
+			expected:    0,
+		},
+		{
+			description: "Newer build of same release",
+			a:           "3.17.0 0.9.1 v6015.0 Build 171020 Rel.70001n",
+			b:           validFirmwareVersion,
+			expected:    1,
+		},
+		{
+			description: "Release without build date",
+			a:           "3.17.0",
+			b:           validFirmwareVersion,
+			expected:    0,
+		},
+	}
+
+	for _, tt := range testCases {
+		got, err := CompareFirmwareVersions(tt.a, tt.b)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tCompareFirmwareVersions(%q, %q) did not return an expected error", tt.description, tt.a, tt.b)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\tCompareFirmwareVersions(%q, %q) returned an unexpected error: %v",
+					tt.description, tt.a, tt.b, err)
+			}
+			if got != tt.expected {
+				t.Fatalf("FAIL: %s\n\tCompareFirmwareVersions(%q, %q) returned %d, want %d",
+					tt.description, tt.a, tt.b, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
@@ -0,0 +1,128 @@
+package archerc9v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/url"
+	"strconv"
+)
+
+const guestNetworkPage = "userRpm/GuestNetWirelessCfgRpm.htm"
+
+// GuestNetwork represents the guest wireless network of one of the router's radios.
+// If SecurityEnabled is true, the network is secured with WPA2-Personal using the
+// Passphrase. Guests may see each other and access the router's LAN only if the
+// corresponding toggles are set. If BandwidthControl is true, the guests' combined
+// traffic is limited to UploadLimit and DownloadLimit, in Kbps.
+type GuestNetwork struct {
+	Band              Band   `json:"band"`
+	Enabled           bool   `json:"enabled"`
+	SSID              string `json:"ssid"`
+	SecurityEnabled   bool   `json:"security_enabled"`
+	Passphrase        string `json:"passphrase"`
+	AllowSeeEachOther bool   `json:"allow_see_each_other"`
+	AllowLANAccess    bool   `json:"allow_lan_access"`
+	BandwidthControl  bool   `json:"bandwidth_control"`
+	UploadLimit       int    `json:"upload_limit_kbps"`
+	DownloadLimit     int    `json:"download_limit_kbps"`
+}
+
+// GetGuestNetwork wraps GetGuestNetworkContext using context.Background.
+func (c *Client) GetGuestNetwork(band Band) (*GuestNetwork, error) {
+	return c.GetGuestNetworkContext(context.Background(), band)
+}
+
+// GetGuestNetworkContext returns the settings of the guest network on the given
+// band or returns an error otherwise.
+func (c *Client) GetGuestNetworkContext(ctx context.Context, band Band) (*GuestNetwork, error) {
+	data, err := c.readPage(ctx, band.page(guestNetworkPage), nil, "get "+band.String()+" guest network")
+	if err != nil {
+		return nil, err
+	}
+
+	para, err := parseJSArray(data, "guestNetPara")
+	if err != nil {
+		return nil, errors.Wrap(err, "got error parsing "+band.String()+" guest network page")
+	}
+
+	g := &GuestNetwork{Band: band, SSID: para.strAt(1), Passphrase: para.strAt(3)}
+	if g.Enabled, err = para.boolAt(0); err != nil {
+		return nil, errors.Wrap(err, "got invalid guest network state")
+	}
+	secType, err := para.intAt(2)
+	if err != nil {
+		return nil, errors.Wrap(err, "got invalid guest network security type")
+	}
+	g.SecurityEnabled = secType == pskSecurityType
+	if g.AllowSeeEachOther, err = para.boolAt(4); err != nil {
+		return nil, errors.Wrap(err, "got invalid guest network isolation setting")
+	}
+	if g.AllowLANAccess, err = para.boolAt(5); err != nil {
+		return nil, errors.Wrap(err, "got invalid guest network LAN access setting")
+	}
+	if g.BandwidthControl, err = para.boolAt(6); err != nil {
+		return nil, errors.Wrap(err, "got invalid guest network bandwidth control setting")
+	}
+	if g.UploadLimit, err = para.intAt(7); err != nil {
+		return nil, errors.Wrap(err, "got invalid guest network upload limit")
+	}
+	if g.DownloadLimit, err = para.intAt(8); err != nil {
+		return nil, errors.Wrap(err, "got invalid guest network download limit")
+	}
+	return g, nil
+}
+
+// SetGuestNetwork wraps SetGuestNetworkContext using context.Background.
+func (c *Client) SetGuestNetwork(g *GuestNetwork) error {
+	return c.SetGuestNetworkContext(context.Background(), g)
+}
+
+// SetGuestNetworkContext changes the settings of the guest network on g.Band to g.
+// An error is returned if the settings are invalid or cannot be applied.
+func (c *Client) SetGuestNetworkContext(ctx context.Context, g *GuestNetwork) error {
+	if g == nil {
+		return errors.New("got nil guest network (want non-nil guest network)")
+	}
+	if err := g.Validate(); err != nil {
+		return errors.Wrap(err, "got invalid guest network settings")
+	}
+
+	q := url.Values{}
+	q.Set("enable", boolParam(g.Enabled))
+	q.Set("ssid", g.SSID)
+	q.Set("secType", "0")
+	if g.SecurityEnabled {
+		q.Set("secType", strconv.Itoa(pskSecurityType))
+	}
+	q.Set("pskSecret", g.Passphrase)
+	q.Set("isolation", boolParam(!g.AllowSeeEachOther))
+	q.Set("accessLan", boolParam(g.AllowLANAccess))
+	q.Set("bwCtrl", boolParam(g.BandwidthControl))
+	q.Set("upBw", strconv.Itoa(g.UploadLimit))
+	q.Set("downBw", strconv.Itoa(g.DownloadLimit))
+	q.Set("Save", "Save")
+
+	_, err := c.getPage(ctx, g.Band.page(guestNetworkPage), q, "set "+g.Band.String()+" guest network")
+	return err
+}
+
+// Validate checks that g describes guest network settings the router accepts.
+func (g *GuestNetwork) Validate() error {
+	if g.Band != Band24GHz && g.Band != Band5GHz {
+		return fmt.Errorf("got band %s (want 2.4GHz or 5GHz)", g.Band)
+	}
+	if len(g.SSID) == 0 || len(g.SSID) > 32 {
+		return fmt.Errorf("got guest SSID %q (want 1 to 32 characters)", g.SSID)
+	}
+	if g.SecurityEnabled {
+		if err := validatePassphrase(g.Passphrase); err != nil {
+			return errors.Wrap(err, "got invalid guest passphrase")
+		}
+	}
+	if g.BandwidthControl && (g.UploadLimit <= 0 || g.DownloadLimit <= 0) {
+		return fmt.Errorf("got bandwidth limits of %d Kbps up and %d Kbps down (want positive limits)",
+			g.UploadLimit, g.DownloadLimit)
+	}
+	return nil
+}
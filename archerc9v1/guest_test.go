@@ -0,0 +1,162 @@
+package archerc9v1
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var validGuestNetworkPage = `<script type="text/javascript">
+var guestNetPara = new Array(
+1,
+"HomeNet_Guest",
+3,
+"guest passphrase",
+0,
+0,
+1,
+1024,
+8192,
+0,0 );
+</script>`
+
+func TestClient_GetGuestNetwork(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       RoundTripFunc
+		expected    *GuestNetwork
+		expectError bool
+	}{
+		{
+			description: "404 status code in response",
+			input:       servePages(nil),
+			expectError: true,
+		},
+		{
+			description: "Missing bandwidth limits",
+			input: servePages(map[string]string{"/" + guestNetworkPage: `
+var guestNetPara = new Array(1, "HomeNet_Guest", 3, "guest passphrase", 0, 0, 1);`}),
+			expectError: true,
+		},
+		{
+			description: "Valid response",
+			input:       servePages(map[string]string{"/" + guestNetworkPage: validGuestNetworkPage}),
+			expected: &GuestNetwork{
+				Band:             Band24GHz,
+				Enabled:          true,
+				SSID:             "HomeNet_Guest",
+				SecurityEnabled:  true,
+				Passphrase:       "guest passphrase",
+				BandwidthControl: true,
+				UploadLimit:      1024,
+				DownloadLimit:    8192,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		client = newPageTestClient(tt.input)
+		got, err := client.GetGuestNetwork(Band24GHz)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetGuestNetwork(2.4GHz) did not return an expected error",
+					tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetGuestNetwork(2.4GHz) returned an unexpected error: %v",
+					tt.description, client, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.GetGuestNetwork(2.4GHz) returned %+v, want %+v",
+					tt.description, client, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_SetGuestNetwork(t *testing.T) {
+	var saved url.Values
+	var savedPath string
+	client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		saved, savedPath = r.URL.Query(), r.URL.Path
+		return newPageResponse(r, 200, validGuestNetworkPage), nil
+	})
+
+	invalid := &GuestNetwork{Band: Band5GHz, Enabled: true, SSID: "HomeNet_Guest", BandwidthControl: true}
+	if err := client.SetGuestNetwork(invalid); err == nil || saved != nil {
+		t.Fatalf("FAIL: Bandwidth control without limits\n\t%v.SetGuestNetwork(%+v) returned %v and submitted %v, want an error and nothing submitted",
+			client, invalid, err, saved)
+	}
+	t.Logf("PASS: Bandwidth control without limits")
+
+	g := &GuestNetwork{
+		Band:              Band5GHz,
+		Enabled:           true,
+		SSID:              "HomeNet_Guest",
+		AllowSeeEachOther: true,
+	}
+	if err := client.SetGuestNetwork(g); err != nil {
+		t.Fatalf("FAIL: Open guest network\n\t%v.SetGuestNetwork(%+v) returned an unexpected error: %v",
+			client, g, err)
+	}
+	expected := url.Values{
+		"enable":    {"1"},
+		"ssid":      {"HomeNet_Guest"},
+		"secType":   {"0"},
+		"pskSecret": {""},
+		"isolation": {"0"},
+		"accessLan": {"0"},
+		"bwCtrl":    {"0"},
+		"upBw":      {"0"},
+		"downBw":    {"0"},
+		"Save":      {"Save"},
+	}
+	if savedPath != "/userRpm/GuestNetWirelessCfgRpm_5g.htm" || !reflect.DeepEqual(saved, expected) {
+		t.Fatalf("FAIL: Open guest network\n\t%v.SetGuestNetwork(%+v) submitted %s?%v, want %s?%v",
+			client, g, savedPath, saved, "/userRpm/GuestNetWirelessCfgRpm_5g.htm", expected)
+	}
+	t.Logf("PASS: Open guest network")
+}
+
+func TestGuestNetwork_Validate(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       *GuestNetwork
+		expectError bool
+	}{
+		{
+			description: "Secured guest network",
+			input:       &GuestNetwork{Band: Band24GHz, SSID: "Guest", SecurityEnabled: true, Passphrase: "passphrase"},
+		},
+		{
+			description: "Empty SSID",
+			input:       &GuestNetwork{Band: Band24GHz, SSID: ""},
+			expectError: true,
+		},
+		{
+			description: "Secured without passphrase",
+			input:       &GuestNetwork{Band: Band24GHz, SSID: "Guest", SecurityEnabled: true},
+			expectError: true,
+		},
+		{
+			description: "Negative upload limit",
+			input:       &GuestNetwork{Band: Band24GHz, SSID: "Guest", BandwidthControl: true, UploadLimit: -1, DownloadLimit: 1},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		err := tt.input.Validate()
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%+v.Validate() did not return an expected error", tt.description, tt.input)
+			}
+		} else if err != nil {
+			t.Fatalf("FAIL: %s\n\t%+v.Validate() returned an unexpected error: %v", tt.description, tt.input, err)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
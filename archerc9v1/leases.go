@@ -0,0 +1,84 @@
+package archerc9v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	leasesPage = "userRpm/AssignedIpAddrListRpm.htm"
+
+	// permanentLease is how the router shows the lease time of addresses
+	// that never expire, such as static reservations.
+	permanentLease = "Permanent"
+)
+
+// Lease represents an IP address handed out by the router's DHCP server. Remaining
+// is the time left until the lease expires and is zero for permanent leases.
+type Lease struct {
+	HostName   string        `json:"name"`
+	MacAddress string        `json:"mac_addr"`
+	IPAddress  string        `json:"ip_addr"`
+	Remaining  time.Duration `json:"remaining"`
+	Permanent  bool          `json:"permanent"`
+}
+
+// GetDHCPLeases wraps GetDHCPLeasesContext using context.Background.
+func (c *Client) GetDHCPLeases() ([]*Lease, error) {
+	return c.GetDHCPLeasesContext(context.Background())
+}
+
+// GetDHCPLeasesContext returns the leases in the router's DHCP client list or
+// returns an error otherwise.
+func (c *Client) GetDHCPLeasesContext(ctx context.Context) ([]*Lease, error) {
+	data, err := c.readPage(ctx, leasesPage, nil, "get DHCP leases")
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := parseJSArray(data, "DHCPDynList")
+	if err != nil {
+		return nil, errors.Wrap(err, "got error parsing DHCP client list page")
+	}
+
+	var leases []*Lease
+	for _, row := range list.rows(4) {
+		l := &Lease{
+			HostName:   row.strAt(0),
+			MacAddress: row.strAt(1),
+			IPAddress:  row.strAt(2),
+		}
+		if l.Remaining, l.Permanent, err = parseLeaseTime(row.strAt(3)); err != nil {
+			return nil, errors.Wrap(err, "got invalid lease time for "+l.MacAddress)
+		}
+		leases = append(leases, l)
+	}
+	return leases, nil
+}
+
+// parseLeaseTime parses a lease time as shown by the router, either
+// "Permanent" or the remaining time as HH:MM:SS, where the hours may
+// exceed 24.
+func parseLeaseTime(s string) (remaining time.Duration, permanent bool, err error) {
+	if strings.EqualFold(s, permanentLease) {
+		return 0, true, nil
+	}
+
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, false, fmt.Errorf("got lease time %q (want HH:MM:SS or %s)", s, permanentLease)
+	}
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || (i > 0 && n > 59) {
+			return 0, false, fmt.Errorf("got lease time %q (want HH:MM:SS or %s)", s, permanentLease)
+		}
+		remaining += time.Duration(n) * units[i]
+	}
+	return remaining, false, nil
+}
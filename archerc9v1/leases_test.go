@@ -0,0 +1,120 @@
+package archerc9v1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestClient_GetDHCPLeases(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       RoundTripFunc
+		expected    []*Lease
+		expectError bool
+	}{
+		{
+			description: "404 status code in response",
+			input:       servePages(nil),
+			expectError: true,
+		},
+		{
+			description: "Page without DHCP client list",
+			input:       servePages(map[string]string{"/" + leasesPage: "<html></html>"}),
+			expectError: true,
+		},
+		{
+			description: "Invalid lease time",
+			input: servePages(map[string]string{"/" + leasesPage: `
+var DHCPDynList = new Array("FakeHost", "12-34-56-AA-BB-CC", "192.168.0.100", "soon", 0,0 );`}),
+			expectError: true,
+		},
+		{
+			description: "No leases",
+			input: servePages(map[string]string{"/" + leasesPage: `
+var DHCPDynList = new Array(0,0 );`}),
+			expected: nil,
+		},
+		{
+			description: "Valid response",
+			input: servePages(map[string]string{"/" + leasesPage: `
+var DHCPDynList = new Array(
+"FakeHost", "12-34-56-AA-BB-CC", "192.168.0.100", "01:59:23",
+"printer", "1A-1A-1A-AA-AA-AA", "192.168.0.150", "Permanent",
+0,0 );`}),
+			expected: []*Lease{
+				{
+					HostName:   "FakeHost",
+					MacAddress: "12-34-56-AA-BB-CC",
+					IPAddress:  "192.168.0.100",
+					Remaining:  time.Hour + 59*time.Minute + 23*time.Second,
+				},
+				{
+					HostName:   "printer",
+					MacAddress: "1A-1A-1A-AA-AA-AA",
+					IPAddress:  "192.168.0.150",
+					Permanent:  true,
+				},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		client = newPageTestClient(tt.input)
+		got, err := client.GetDHCPLeases()
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetDHCPLeases() did not return an expected error",
+					tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetDHCPLeases() returned an unexpected error: %v",
+					tt.description, client, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.GetDHCPLeases() returned %v, want %v",
+					tt.description, client, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestParseLeaseTime(t *testing.T) {
+	testCases := []*struct {
+		description       string
+		input             string
+		expectedRemaining time.Duration
+		expectedPermanent bool
+		expectError       bool
+	}{
+		{description: "Permanent", input: "Permanent", expectedPermanent: true},
+		{description: "Less than an hour", input: "00:05:09", expectedRemaining: 5*time.Minute + 9*time.Second},
+		{description: "More than a day", input: "47:00:01", expectedRemaining: 47*time.Hour + time.Second},
+		{description: "Missing seconds", input: "01:00", expectError: true},
+		{description: "Minutes out of range", input: "01:60:00", expectError: true},
+		{description: "Negative hours", input: "-1:00:00", expectError: true},
+		{description: "Empty lease time", input: "", expectError: true},
+	}
+
+	for _, tt := range testCases {
+		remaining, permanent, err := parseLeaseTime(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tparseLeaseTime(%q) did not return an expected error",
+					tt.description, tt.input)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\tparseLeaseTime(%q) returned an unexpected error: %v",
+					tt.description, tt.input, err)
+			}
+			if remaining != tt.expectedRemaining || permanent != tt.expectedPermanent {
+				t.Fatalf("FAIL: %s\n\tparseLeaseTime(%q) returned (%s, %t), want (%s, %t)",
+					tt.description, tt.input, remaining, permanent, tt.expectedRemaining, tt.expectedPermanent)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
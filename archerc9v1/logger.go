@@ -0,0 +1,198 @@
+package archerc9v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Logger is a leveled, structured logger. The keyvals of each message are
+// alternating keys and values that describe it, e.g. "method", "GET", "status",
+// 200. The Client logs each request at the debug level, retries at the warn
+// level and the progress of long running actions like reboots at the info level.
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+	Info(msg string, keyvals ...interface{})
+	Warn(msg string, keyvals ...interface{})
+	Error(msg string, keyvals ...interface{})
+}
+
+// logger is the logging interface the Client used before Logger. PrintfLogger
+// adapts it to Logger.
+type logger interface {
+	Printf(string, ...interface{})
+}
+
+// Level is the severity of a message logged with a Logger.
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// levelNames holds the names of the levels, indexed by Level.
+var levelNames = []string{"debug", "info", "warn", "error"}
+
+// String returns the name of the level.
+func (l Level) String() string {
+	if l >= 0 && int(l) < len(levelNames) {
+		return levelNames[l]
+	}
+	return "Level(" + strconv.Itoa(int(l)) + ")"
+}
+
+// ParseLevel returns the Level named by s, e.g. info or WARN.
+func ParseLevel(s string) (Level, error) {
+	if strings.EqualFold(s, "warning") {
+		return LevelWarn, nil
+	}
+	for i, name := range levelNames {
+		if strings.EqualFold(s, name) {
+			return Level(i), nil
+		}
+	}
+	return 0, fmt.Errorf("got log level %q (want one of %s)", s, strings.Join(levelNames, ", "))
+}
+
+// Format is the output format of a Logger returned by NewStdLogger.
+type Format int
+
+const (
+	// TextFormat writes the level and message followed by key=value pairs.
+	TextFormat Format = iota
+	// JSONFormat writes a JSON object with the time, level, message and
+	// key-value pairs.
+	JSONFormat
+)
+
+// ParseFormat returns the Format named by s, which is text or json.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(s) {
+	case "text":
+		return TextFormat, nil
+	case "json":
+		return JSONFormat, nil
+	}
+	return 0, fmt.Errorf("got log format %q (want text or json)", s)
+}
+
+// NewStdLogger returns a Logger that writes the messages of the given level or
+// more severe ones to l, one line per message, in the given format. For
+// JSONFormat, l should have neither a prefix nor flags so that each line is a
+// valid JSON object.
+func NewStdLogger(l *log.Logger, level Level, format Format) Logger {
+	return &stdLogger{logger: l, level: level, format: format}
+}
+
+type stdLogger struct {
+	logger *log.Logger
+	level  Level
+	format Format
+}
+
+func (l *stdLogger) Debug(msg string, keyvals ...interface{}) { l.log(LevelDebug, msg, keyvals) }
+func (l *stdLogger) Info(msg string, keyvals ...interface{})  { l.log(LevelInfo, msg, keyvals) }
+func (l *stdLogger) Warn(msg string, keyvals ...interface{})  { l.log(LevelWarn, msg, keyvals) }
+func (l *stdLogger) Error(msg string, keyvals ...interface{}) { l.log(LevelError, msg, keyvals) }
+
+func (l *stdLogger) log(level Level, msg string, keyvals []interface{}) {
+	if level < l.level {
+		return
+	}
+	if l.format == JSONFormat {
+		l.logger.Print(formatJSON(time.Now(), level, msg, keyvals))
+		return
+	}
+	l.logger.Print(formatText(level, msg, keyvals))
+}
+
+// PrintfLogger returns a Logger that writes every message as text to l, which
+// has only a Printf method, e.g. a *log.Logger.
+func PrintfLogger(l logger) Logger {
+	return printfLogger{l}
+}
+
+type printfLogger struct {
+	logger logger
+}
+
+func (l printfLogger) Debug(msg string, keyvals ...interface{}) { l.log(LevelDebug, msg, keyvals) }
+func (l printfLogger) Info(msg string, keyvals ...interface{})  { l.log(LevelInfo, msg, keyvals) }
+func (l printfLogger) Warn(msg string, keyvals ...interface{})  { l.log(LevelWarn, msg, keyvals) }
+func (l printfLogger) Error(msg string, keyvals ...interface{}) { l.log(LevelError, msg, keyvals) }
+
+func (l printfLogger) log(level Level, msg string, keyvals []interface{}) {
+	l.logger.Printf("%s", formatText(level, msg, keyvals))
+}
+
+// formatText formats a message as its upper case level and msg followed by the
+// key=value pairs of keyvals. Values with spaces or quotes are quoted.
+func formatText(level Level, msg string, keyvals []interface{}) string {
+	var b strings.Builder
+	b.WriteString(strings.ToUpper(level.String()))
+	b.WriteByte(' ')
+	b.WriteString(msg)
+	for i := 0; i < len(keyvals); i += 2 {
+		b.WriteByte(' ')
+		b.WriteString(fmt.Sprint(keyvals[i]))
+		b.WriteByte('=')
+		v := fmt.Sprint(logValue(keyvals, i+1))
+		if v == "" || strings.ContainsAny(v, " \t\n\"=") {
+			v = strconv.Quote(v)
+		}
+		b.WriteString(v)
+	}
+	return b.String()
+}
+
+// formatJSON formats a message as a JSON object with the time, level, msg and
+// the key-value pairs of keyvals as its fields, in that order.
+func formatJSON(t time.Time, level Level, msg string, keyvals []interface{}) string {
+	var b strings.Builder
+	b.WriteByte('{')
+	writeJSONField(&b, "time", t.Format(time.RFC3339Nano))
+	b.WriteByte(',')
+	writeJSONField(&b, "level", level.String())
+	b.WriteByte(',')
+	writeJSONField(&b, "msg", msg)
+	for i := 0; i < len(keyvals); i += 2 {
+		b.WriteByte(',')
+		writeJSONField(&b, fmt.Sprint(keyvals[i]), logValue(keyvals, i+1))
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func writeJSONField(b *strings.Builder, key string, value interface{}) {
+	k, _ := json.Marshal(key)
+	b.Write(k)
+	b.WriteByte(':')
+	v, err := json.Marshal(value)
+	if err != nil {
+		v, _ = json.Marshal(fmt.Sprint(value))
+	}
+	b.Write(v)
+}
+
+// logValue returns the value at index i of keyvals in a form that formats well
+// as text and JSON. Errors and fmt.Stringers, e.g. time.Duration and *url.URL,
+// are turned into strings.
+func logValue(keyvals []interface{}, i int) interface{} {
+	if i >= len(keyvals) {
+		return "(MISSING)"
+	}
+	switch v := keyvals[i].(type) {
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return v
+	}
+}
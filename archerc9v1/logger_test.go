@@ -0,0 +1,207 @@
+package archerc9v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLevel(t *testing.T) {
+	testCases := []*struct {
+		input       string
+		expected    Level
+		expectError bool
+	}{
+		{input: "debug", expected: LevelDebug},
+		{input: "INFO", expected: LevelInfo},
+		{input: "warn", expected: LevelWarn},
+		{input: "Warning", expected: LevelWarn},
+		{input: "error", expected: LevelError},
+		{input: "verbose", expectError: true},
+		{input: "", expectError: true},
+	}
+
+	for _, tt := range testCases {
+		got, err := ParseLevel(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: ParseLevel(%q) did not return an expected error", tt.input)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: ParseLevel(%q) returned an unexpected error: %v", tt.input, err)
+			}
+			if got != tt.expected {
+				t.Fatalf("FAIL: ParseLevel(%q) returned %s, want %s", tt.input, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: ParseLevel(%q)", tt.input)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, tt := range []*struct {
+		input       string
+		expected    Format
+		expectError bool
+	}{
+		{input: "text", expected: TextFormat},
+		{input: "JSON", expected: JSONFormat},
+		{input: "xml", expectError: true},
+	} {
+		got, err := ParseFormat(tt.input)
+		if tt.expectError != (err != nil) || got != tt.expected {
+			t.Fatalf("FAIL: ParseFormat(%q) returned %v, %v (want %v and an error: %t)",
+				tt.input, got, err, tt.expected, tt.expectError)
+		}
+		t.Logf("PASS: ParseFormat(%q)", tt.input)
+	}
+}
+
+func TestNewStdLogger(t *testing.T) {
+	testCases := []*struct {
+		description string
+		level       Level
+		format      Format
+		log         func(Logger)
+		expected    string
+	}{
+		{
+			description: "Messages below the level dropped",
+			level:       LevelWarn,
+			format:      TextFormat,
+			log: func(l Logger) {
+				l.Debug("sending request")
+				l.Info("rebooting")
+			},
+			expected: "",
+		},
+		{
+			description: "Text with key-value pairs",
+			level:       LevelDebug,
+			format:      TextFormat,
+			log: func(l Logger) {
+				l.Warn("retrying request", "method", "GET", "url", validURL, "status", 503, "backoff", 500*time.Millisecond)
+			},
+			expected: "WARN retrying request method=GET url=http://my-tplink-rtr/ status=503 backoff=500ms\n",
+		},
+		{
+			description: "Text with quoted values and a missing value",
+			level:       LevelInfo,
+			format:      TextFormat,
+			log: func(l Logger) {
+				l.Error("request failed", "error", errors.New("connection reset"), "empty", "", "odd")
+			},
+			expected: `ERROR request failed error="connection reset" empty="" odd=(MISSING)` + "\n",
+		},
+	}
+
+	for _, tt := range testCases {
+		var buf bytes.Buffer
+		tt.log(NewStdLogger(log.New(&buf, "", 0), tt.level, tt.format))
+		if buf.String() != tt.expected {
+			t.Fatalf("FAIL: %s\n\tgot log output %q, want %q", tt.description, buf.String(), tt.expected)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestNewStdLogger_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewStdLogger(log.New(&buf, "", 0), LevelInfo, JSONFormat)
+	l.Debug("sending request")
+	l.Info("got response", "method", "GET", "status", 200, "duration", time.Second, "error", errors.New("none"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("FAIL: got %d log line(s) %q, want 1", len(lines), buf.String())
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &got); err != nil {
+		t.Fatalf("FAIL: got log line %q that is not valid JSON: %v", lines[0], err)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, got["time"].(string)); err != nil {
+		t.Fatalf("FAIL: got log line %q without a valid time: %v", lines[0], err)
+	}
+	for k, v := range map[string]interface{}{"level": "info", "msg": "got response", "method": "GET",
+		"status": float64(200), "duration": "1s", "error": "none"} {
+		if got[k] != v {
+			t.Fatalf("FAIL: got log line %q with %s=%v, want %v", lines[0], k, got[k], v)
+		}
+	}
+	if !strings.HasPrefix(lines[0], `{"time":`) || !strings.Contains(lines[0], `"msg":"got response","method":"GET"`) {
+		t.Fatalf("FAIL: got log line %q, want the time, level and msg first and the fields in order", lines[0])
+	}
+	t.Logf("PASS: JSON log line")
+}
+
+func TestPrintfLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := PrintfLogger(log.New(&buf, "", 0))
+	l.Debug("sending request", "action", "get status")
+	l.Error("reboot failed")
+	expected := "DEBUG sending request action=\"get status\"\nERROR reboot failed\n"
+	if buf.String() != expected {
+		t.Fatalf("FAIL: got log output %q, want %q", buf.String(), expected)
+	}
+	t.Logf("PASS: PrintfLogger logs every level")
+}
+
+func TestClient_logRedactsSecrets(t *testing.T) {
+	const passphrase, password = "correct horse battery", "hunter2-pppoe"
+	testCases := []*struct {
+		description string
+		input       RoundTripFunc
+	}{
+		{
+			description: "500 status code in response",
+			input: func(r *http.Request) (*http.Response, error) {
+				return newPageResponse(r, 500, ""), nil
+			},
+		},
+		{
+			description: "Failed request",
+			input: func(r *http.Request) (*http.Response, error) {
+				return nil, errors.New("connection reset")
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		var buf bytes.Buffer
+		client, _ = NewClient(validRawURL, WithCredentials(user, password), WithHTTPClient(NewTestClient(tt.input)),
+			WithLogger(NewStdLogger(log.New(&buf, "", 0), LevelDebug, TextFormat)))
+		errs := []error{
+			client.SetWirelessSecurity(&WirelessSecurity{Band: Band24GHz, Enabled: true, Version: "wpa2",
+				Cipher: "aes", Passphrase: passphrase}),
+			client.SetWANSettings(&WANSettings{Type: WANPPPoE,
+				PPPoE: &PPPoEConfig{UserName: "user@isp", Password: password}}),
+		}
+		for _, err := range errs {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tgot no error, want one", tt.description)
+			}
+			for _, secret := range []string{passphrase, url.QueryEscape(passphrase), password} {
+				if strings.Contains(err.Error(), secret) {
+					t.Fatalf("FAIL: %s\n\tgot error %q containing the secret %q", tt.description, err, secret)
+				}
+			}
+		}
+		for _, secret := range []string{passphrase, url.QueryEscape(passphrase), password} {
+			if strings.Contains(buf.String(), secret) {
+				t.Fatalf("FAIL: %s\n\tgot log output %q containing the secret %q", tt.description, buf.String(), secret)
+			}
+		}
+		if !strings.Contains(buf.String(), "pskSecret="+redactedValue) || !strings.Contains(buf.String(), "psw="+redactedValue) {
+			t.Fatalf("FAIL: %s\n\tgot log output %q, want the requests logged with redacted secrets",
+				tt.description, buf.String())
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
@@ -0,0 +1,23 @@
+package archerc9v1
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// NormalizeMAC returns mac in the upper case, dash separated format the router
+// uses for MAC addresses (e.g. 12-34-56-AA-BB-CC). MAC addresses separated by
+// colons, dashes or dots as well as bare hex digits (e.g. 123456aabbcc) are
+// accepted.
+func NormalizeMAC(mac string) (string, error) {
+	s := strings.TrimSpace(mac)
+	if len(s) == 12 && strings.Trim(strings.ToLower(s), "0123456789abcdef") == "" {
+		s = strings.Join([]string{s[0:2], s[2:4], s[4:6], s[6:8], s[8:10], s[10:12]}, "-")
+	}
+	hw, err := net.ParseMAC(s)
+	if err != nil || len(hw) != 6 {
+		return "", fmt.Errorf("got invalid MAC address %q (want a 48-bit MAC address such as 12-34-56-AA-BB-CC)", mac)
+	}
+	return strings.ToUpper(strings.Replace(hw.String(), ":", "-", -1)), nil
+}
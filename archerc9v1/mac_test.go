@@ -0,0 +1,42 @@
+package archerc9v1
+
+import "testing"
+
+func TestNormalizeMAC(t *testing.T) {
+	testCases := []*struct {
+		description, input, expected string
+		expectError                  bool
+	}{
+		{description: "Dash separated", input: "12-34-56-aa-bb-cc", expected: "12-34-56-AA-BB-CC"},
+		{description: "Colon separated", input: "12:34:56:aa:bb:cc", expected: "12-34-56-AA-BB-CC"},
+		{description: "Dot separated", input: "1234.56aa.bbcc", expected: "12-34-56-AA-BB-CC"},
+		{description: "Bare hex digits", input: "123456aabbcc", expected: "12-34-56-AA-BB-CC"},
+		{description: "Surrounding whitespace", input: " 12-34-56-AA-BB-CC\n", expected: "12-34-56-AA-BB-CC"},
+		{description: "Empty MAC address", input: "", expectError: true},
+		{description: "Too short", input: "12-34-56-AA-BB", expectError: true},
+		{description: "64-bit MAC address", input: "12-34-56-AA-BB-CC-DD-EE", expectError: true},
+		{description: "Bare hex digits too short", input: "123456aabbc", expectError: true},
+		{description: "Bare non-hex characters", input: "123456aabbcx", expectError: true},
+		{description: "Invalid characters", input: "12-34-56-AA-BB-XX", expectError: true},
+	}
+
+	for _, tt := range testCases {
+		got, err := NormalizeMAC(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tNormalizeMAC(%q) did not return an expected error",
+					tt.description, tt.input)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\tNormalizeMAC(%q) returned an unexpected error: %v",
+					tt.description, tt.input, err)
+			}
+			if got != tt.expected {
+				t.Fatalf("FAIL: %s\n\tNormalizeMAC(%q) returned %q, want %q",
+					tt.description, tt.input, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
@@ -0,0 +1,236 @@
+package archerc9v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const macFilterPage = "userRpm/WlanMacFilterRpm.htm"
+
+// MACFilterMode is the mode of the router's wireless MAC filtering.
+type MACFilterMode int
+
+const (
+	// MACFilterDisabled lets all nodes connect to the wireless networks.
+	MACFilterDisabled MACFilterMode = iota
+	// MACFilterDenyList keeps the nodes with an enabled entry from connecting.
+	MACFilterDenyList
+	// MACFilterAllowList lets only the nodes with an enabled entry connect.
+	MACFilterAllowList
+)
+
+// String returns the name of the mode.
+func (m MACFilterMode) String() string {
+	switch m {
+	case MACFilterDisabled:
+		return "disabled"
+	case MACFilterDenyList:
+		return "deny-list"
+	case MACFilterAllowList:
+		return "allow-list"
+	}
+	return "MACFilterMode(" + strconv.Itoa(int(m)) + ")"
+}
+
+// ParseMACFilterMode returns the MACFilterMode named by s, which is one of
+// disabled, deny-list (or deny) and allow-list (or allow).
+func ParseMACFilterMode(s string) (MACFilterMode, error) {
+	switch strings.ToLower(s) {
+	case "disabled", "off":
+		return MACFilterDisabled, nil
+	case "deny-list", "deny", "blacklist":
+		return MACFilterDenyList, nil
+	case "allow-list", "allow", "whitelist":
+		return MACFilterAllowList, nil
+	}
+	return 0, fmt.Errorf("got MAC filter mode %q (want disabled, deny-list or allow-list)", s)
+}
+
+// MACFilterEntry represents an entry of the router's wireless MAC filter. The
+// ID is the position of the entry in the router's list, counting across all of
+// its pages.
+type MACFilterEntry struct {
+	ID          int    `json:"id"`
+	MacAddress  string `json:"mac_addr"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+
+	// pos is where the entry is shown on the router's MAC filter pages.
+	pos listPosition
+}
+
+// GetMACFilterMode wraps GetMACFilterModeContext using context.Background.
+func (c *Client) GetMACFilterMode() (MACFilterMode, error) {
+	return c.GetMACFilterModeContext(context.Background())
+}
+
+// GetMACFilterModeContext returns the mode of the router's wireless MAC filtering
+// or returns an error otherwise.
+func (c *Client) GetMACFilterModeContext(ctx context.Context) (MACFilterMode, error) {
+	data, err := c.readPage(ctx, macFilterPage, nil, "get MAC filter mode")
+	if err != nil {
+		return 0, err
+	}
+
+	para, err := parseJSArray(data, "wlanFilterPara")
+	if err != nil {
+		return 0, errors.Wrap(err, "got error parsing MAC filter page")
+	}
+	enabled, err := para.boolAt(0)
+	if err != nil {
+		return 0, errors.Wrap(err, "got invalid MAC filter state")
+	}
+	if !enabled {
+		return MACFilterDisabled, nil
+	}
+	rule, err := para.intAt(1)
+	if err != nil {
+		return 0, errors.Wrap(err, "got invalid MAC filter rule")
+	}
+	switch rule {
+	case 1:
+		return MACFilterDenyList, nil
+	case 2:
+		return MACFilterAllowList, nil
+	}
+	return 0, fmt.Errorf("got unknown MAC filter rule code %d", rule)
+}
+
+// SetMACFilterMode wraps SetMACFilterModeContext using context.Background.
+func (c *Client) SetMACFilterMode(mode MACFilterMode) error {
+	return c.SetMACFilterModeContext(context.Background(), mode)
+}
+
+// SetMACFilterModeContext changes the mode of the router's wireless MAC filtering.
+// Beware that switching to MACFilterAllowList disconnects every node without an
+// enabled entry, possibly including the one running this client.
+func (c *Client) SetMACFilterModeContext(ctx context.Context, mode MACFilterMode) error {
+	q := url.Values{}
+	switch mode {
+	case MACFilterDisabled:
+		q.Set("Disfilter", "1")
+	case MACFilterDenyList:
+		q.Set("Enfilter", "1")
+		q.Set("rule", "1")
+	case MACFilterAllowList:
+		q.Set("Enfilter", "1")
+		q.Set("rule", "2")
+	default:
+		return fmt.Errorf("got MAC filter mode %s (want disabled, deny-list or allow-list)", mode)
+	}
+	q.Set("Page", "1")
+	_, err := c.getPage(ctx, macFilterPage, q, "set MAC filter mode")
+	return err
+}
+
+// ListMACFilterEntries wraps ListMACFilterEntriesContext using context.Background.
+func (c *Client) ListMACFilterEntries() ([]*MACFilterEntry, error) {
+	return c.ListMACFilterEntriesContext(context.Background())
+}
+
+// ListMACFilterEntriesContext returns the entries of the router's wireless MAC
+// filter or returns an error otherwise.
+func (c *Client) ListMACFilterEntriesContext(ctx context.Context) ([]*MACFilterEntry, error) {
+	rows, err := c.getListRows(ctx, macFilterPage, "list MAC filter entries",
+		parseListRows("wlanFilterList", 3, "MAC filter page"))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*MACFilterEntry
+	for i, row := range rows {
+		enabled, err := row.boolAt(2)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("got invalid state for MAC filter entry %d", i))
+		}
+		entries = append(entries, &MACFilterEntry{
+			ID:          i,
+			MacAddress:  row.strAt(0),
+			Description: row.strAt(1),
+			Enabled:     enabled,
+			pos:         row.pos,
+		})
+	}
+	return entries, nil
+}
+
+// AddMACFilterEntry wraps AddMACFilterEntryContext using context.Background.
+func (c *Client) AddMACFilterEntry(macAddress, description string) error {
+	return c.AddMACFilterEntryContext(context.Background(), macAddress, description)
+}
+
+// AddMACFilterEntryContext adds an enabled entry for the given MAC address, which
+// may be in any format accepted by NormalizeMAC, to the router's wireless MAC
+// filter. An error is returned if the MAC address already has an entry.
+func (c *Client) AddMACFilterEntryContext(ctx context.Context, macAddress, description string) error {
+	mac, err := NormalizeMAC(macAddress)
+	if err != nil {
+		return err
+	}
+
+	e, err := c.findMACFilterEntry(ctx, mac)
+	if err != nil {
+		return err
+	}
+	if e != nil {
+		return fmt.Errorf("got MAC address %s that already has a MAC filter entry (want a MAC address without an entry)", mac)
+	}
+
+	q := url.Values{}
+	q.Set("Mac", mac)
+	q.Set("Desc", description)
+	q.Set("entryEnabled", "1")
+	q.Set("Changed", "0")
+	q.Set("SelIndex", "0")
+	q.Set("Page", "1")
+	q.Set("Save", "Save")
+	_, err = c.getPage(ctx, macFilterPage, q, "add MAC filter entry")
+	return err
+}
+
+// DeleteMACFilterEntry wraps DeleteMACFilterEntryContext using context.Background.
+func (c *Client) DeleteMACFilterEntry(macAddress string) error {
+	return c.DeleteMACFilterEntryContext(context.Background(), macAddress)
+}
+
+// DeleteMACFilterEntryContext deletes the entry for the given MAC address, which
+// may be in any format accepted by NormalizeMAC, from the router's wireless MAC
+// filter.
+func (c *Client) DeleteMACFilterEntryContext(ctx context.Context, macAddress string) error {
+	mac, err := NormalizeMAC(macAddress)
+	if err != nil {
+		return err
+	}
+
+	e, err := c.findMACFilterEntry(ctx, mac)
+	if err != nil {
+		return err
+	}
+	if e == nil {
+		return fmt.Errorf("got MAC address %s without a MAC filter entry (want a MAC address with an entry)", mac)
+	}
+
+	q := url.Values{}
+	e.pos.set(q, "Del")
+	_, err = c.getPage(ctx, macFilterPage, q, "delete MAC filter entry")
+	return err
+}
+
+// findMACFilterEntry returns the entry for the normalized MAC address mac or nil
+// if there is none.
+func (c *Client) findMACFilterEntry(ctx context.Context, mac string) (*MACFilterEntry, error) {
+	entries, err := c.ListMACFilterEntriesContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error listing MAC filter entries")
+	}
+	for _, e := range entries {
+		if m, _ := NormalizeMAC(e.MacAddress); m == mac {
+			return e, nil
+		}
+	}
+	return nil, nil
+}
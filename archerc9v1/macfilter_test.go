@@ -0,0 +1,234 @@
+package archerc9v1
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var validMACFilterPage = `<script type="text/javascript">
+var wlanFilterPara = new Array(
+1,
+1,
+0,0 );
+var wlanFilterList = new Array(
+"12-34-56-AA-BB-CC", "noisy laptop", 1,
+"1A-1A-1A-AA-AA-AA", "", 0,
+0,0 );
+</script>`
+
+var validMACFilterPage2 = `<script type="text/javascript">
+var wlanFilterPara = new Array(
+1,
+1,
+0,0 );
+var wlanFilterList = new Array(
+"3C-3C-3C-CC-CC-CC", "game console", 1,
+0,0 );
+</script>`
+
+// newMACFilterTestClient returns a Client for a router serving two MAC filter
+// pages, along with a pointer to the query of the last request that changed
+// the MAC filter.
+func newMACFilterTestClient() (*Client, *url.Values) {
+	changed := new(url.Values)
+	pages := serveListPages(macFilterPage, validMACFilterPage, validMACFilterPage2)
+	return newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		q := r.URL.Query()
+		if len(q) > 1 {
+			*changed = q
+		}
+		return pages(r)
+	}), changed
+}
+
+func TestParseMACFilterMode(t *testing.T) {
+	testCases := []*struct {
+		input       string
+		expected    MACFilterMode
+		expectError bool
+	}{
+		{input: "disabled", expected: MACFilterDisabled},
+		{input: "deny", expected: MACFilterDenyList},
+		{input: "Deny-List", expected: MACFilterDenyList},
+		{input: "allow-list", expected: MACFilterAllowList},
+		{input: "whitelist", expected: MACFilterAllowList},
+		{input: "sometimes", expectError: true},
+	}
+
+	for _, tt := range testCases {
+		got, err := ParseMACFilterMode(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tParseMACFilterMode(%q) did not return an expected error", tt.input, tt.input)
+			}
+		} else if err != nil || got != tt.expected {
+			t.Fatalf("FAIL: %s\n\tParseMACFilterMode(%q) returned (%s, %v), want %s", tt.input, tt.input, got, err, tt.expected)
+		}
+		t.Logf("PASS: %s", tt.input)
+	}
+}
+
+func TestClient_GetMACFilterMode(t *testing.T) {
+	testCases := []*struct {
+		description string
+		page        string
+		expected    MACFilterMode
+		expectError bool
+	}{
+		{description: "Disabled", page: `var wlanFilterPara = new Array(0, 1, 0,0 );`, expected: MACFilterDisabled},
+		{description: "Deny list", page: `var wlanFilterPara = new Array(1, 1, 0,0 );`, expected: MACFilterDenyList},
+		{description: "Allow list", page: `var wlanFilterPara = new Array(1, 2, 0,0 );`, expected: MACFilterAllowList},
+		{description: "Unknown rule", page: `var wlanFilterPara = new Array(1, 3, 0,0 );`, expectError: true},
+		{description: "Missing parameters", page: `<html></html>`, expectError: true},
+	}
+
+	for _, tt := range testCases {
+		client = newPageTestClient(servePages(map[string]string{"/" + macFilterPage: tt.page}))
+		got, err := client.GetMACFilterMode()
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetMACFilterMode() did not return an expected error",
+					tt.description, client)
+			}
+		} else if err != nil || got != tt.expected {
+			t.Fatalf("FAIL: %s\n\t%v.GetMACFilterMode() returned (%s, %v), want %s",
+				tt.description, client, got, err, tt.expected)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_SetMACFilterMode(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       MACFilterMode
+		expected    url.Values
+		expectError bool
+	}{
+		{description: "Disabled", input: MACFilterDisabled, expected: url.Values{"Disfilter": {"1"}, "Page": {"1"}}},
+		{description: "Deny list", input: MACFilterDenyList, expected: url.Values{"Enfilter": {"1"}, "rule": {"1"}, "Page": {"1"}}},
+		{description: "Allow list", input: MACFilterAllowList, expected: url.Values{"Enfilter": {"1"}, "rule": {"2"}, "Page": {"1"}}},
+		{description: "Unknown mode", input: MACFilterMode(7), expectError: true},
+	}
+
+	for _, tt := range testCases {
+		var changed *url.Values
+		client, changed = newMACFilterTestClient()
+		err := client.SetMACFilterMode(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.SetMACFilterMode(%s) did not return an expected error",
+					tt.description, client, tt.input)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.SetMACFilterMode(%s) returned an unexpected error: %v",
+					tt.description, client, tt.input, err)
+			}
+			if !reflect.DeepEqual(*changed, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.SetMACFilterMode(%s) submitted %v, want %v",
+					tt.description, client, tt.input, *changed, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_ListMACFilterEntries(t *testing.T) {
+	client, _ = newMACFilterTestClient()
+	expected := []*MACFilterEntry{
+		{ID: 0, MacAddress: "12-34-56-AA-BB-CC", Description: "noisy laptop", Enabled: true,
+			pos: listPosition{page: 1, index: 0}},
+		{ID: 1, MacAddress: "1A-1A-1A-AA-AA-AA", Description: "", Enabled: false,
+			pos: listPosition{page: 1, index: 1}},
+		{ID: 2, MacAddress: "3C-3C-3C-CC-CC-CC", Description: "game console", Enabled: true,
+			pos: listPosition{page: 2, index: 0}},
+	}
+	got, err := client.ListMACFilterEntries()
+	if err != nil {
+		t.Fatalf("FAIL: Valid response\n\t%v.ListMACFilterEntries() returned an unexpected error: %v", client, err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("FAIL: Valid response\n\t%v.ListMACFilterEntries() returned %v, want %v", client, got, expected)
+	}
+	t.Logf("PASS: Valid response")
+}
+
+func TestClient_AddMACFilterEntry(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       string
+		expected    url.Values
+		expectError bool
+	}{
+		{description: "Invalid MAC address", input: "12-34", expectError: true},
+		{description: "Duplicate MAC address", input: "123456aabbcc", expectError: true},
+		{description: "Duplicate MAC address on second page", input: "3c:3c:3c:cc:cc:cc", expectError: true},
+		{
+			description: "New MAC address",
+			input:       "22:22:22:aa:aa:aa",
+			expected: url.Values{
+				"Mac":          {"22-22-22-AA-AA-AA"},
+				"Desc":         {"tablet"},
+				"entryEnabled": {"1"},
+				"Changed":      {"0"},
+				"SelIndex":     {"0"},
+				"Page":         {"1"},
+				"Save":         {"Save"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		var changed *url.Values
+		client, changed = newMACFilterTestClient()
+		err := client.AddMACFilterEntry(tt.input, "tablet")
+		if tt.expectError {
+			if err == nil || *changed != nil {
+				t.Fatalf("FAIL: %s\n\t%v.AddMACFilterEntry(%s) returned %v and submitted %v, want an error and nothing submitted",
+					tt.description, client, tt.input, err, *changed)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.AddMACFilterEntry(%s) returned an unexpected error: %v",
+					tt.description, client, tt.input, err)
+			}
+			if !reflect.DeepEqual(*changed, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.AddMACFilterEntry(%s) submitted %v, want %v",
+					tt.description, client, tt.input, *changed, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_DeleteMACFilterEntry(t *testing.T) {
+	var changed *url.Values
+	client, changed = newMACFilterTestClient()
+	if err := client.DeleteMACFilterEntry("22-22-22-AA-AA-AA"); err == nil {
+		t.Fatalf("FAIL: MAC address without entry\n\t%v.DeleteMACFilterEntry() did not return an expected error", client)
+	}
+	t.Logf("PASS: MAC address without entry")
+
+	if err := client.DeleteMACFilterEntry("1a1a1aaaaaaa"); err != nil {
+		t.Fatalf("FAIL: MAC address with entry\n\t%v.DeleteMACFilterEntry() returned an unexpected error: %v", client, err)
+	}
+	expected := url.Values{"Del": {"1"}, "Page": {"1"}}
+	if !reflect.DeepEqual(*changed, expected) {
+		t.Fatalf("FAIL: MAC address with entry\n\t%v.DeleteMACFilterEntry() submitted %v, want %v", client, *changed, expected)
+	}
+	t.Logf("PASS: MAC address with entry")
+
+	if err := client.DeleteMACFilterEntry("3c-3c-3c-cc-cc-cc"); err != nil {
+		t.Fatalf("FAIL: MAC address with entry on second page\n\t%v.DeleteMACFilterEntry() returned an unexpected error: %v",
+			client, err)
+	}
+	expected = url.Values{"Del": {"0"}, "Page": {"2"}}
+	if !reflect.DeepEqual(*changed, expected) {
+		t.Fatalf("FAIL: MAC address with entry on second page\n\t%v.DeleteMACFilterEntry() submitted %v, want %v",
+			client, *changed, expected)
+	}
+	t.Logf("PASS: MAC address with entry on second page")
+}
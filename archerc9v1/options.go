@@ -0,0 +1,95 @@
+package archerc9v1
+
+import (
+	"crypto/tls"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client created with NewClient.
+type Option func(*options)
+
+type options struct {
+	userName, password string
+	httpClient         *http.Client
+	logger             Logger
+	timeout            time.Duration
+	retry              *RetryPolicy
+	userAgent          string
+	insecureTLS        bool
+}
+
+// WithCredentials sets the user name and password of the router's admin user.
+// It is required.
+func WithCredentials(userName, password string) Option {
+	return func(o *options) {
+		o.userName, o.password = userName, password
+	}
+}
+
+// WithHTTPClient makes the Client send its requests with httpClient instead of
+// a new http.Client. WithTimeout and WithInsecureTLS apply to a copy of
+// httpClient and leave it unchanged.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = httpClient
+	}
+}
+
+// WithLogger makes the Client log with lgr. By default, the Client logs warnings
+// and errors as text to os.Stderr.
+func WithLogger(lgr Logger) Option {
+	return func(o *options) {
+		o.logger = lgr
+	}
+}
+
+// WithTimeout limits the time each request to the router may take, including
+// reading the response body. By default there is no limit.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) {
+		o.timeout = d
+	}
+}
+
+// WithRetry makes the Client retry requests as described by p, see
+// SetRetryPolicy.
+func WithRetry(p *RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = p
+	}
+}
+
+// WithUserAgent sets the User-Agent header of the requests to the router.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithInsecureTLS makes the Client accept any certificate the router presents
+// over https, e.g. the self-signed one most routers come with. This makes the
+// connection vulnerable to man-in-the-middle attacks.
+func WithInsecureTLS() Option {
+	return func(o *options) {
+		o.insecureTLS = true
+	}
+}
+
+// insecureTransport returns a copy of rt that skips the verification of
+// server certificates, or nil if rt is not an *http.Transport.
+func insecureTransport(rt http.RoundTripper) *http.Transport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		return nil
+	}
+	t = t.Clone()
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{}
+	}
+	t.TLSClientConfig.InsecureSkipVerify = true
+	return t
+}
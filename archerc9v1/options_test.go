@@ -0,0 +1,114 @@
+package archerc9v1
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewClient(t *testing.T) {
+	hc := &http.Client{Transport: &http.Transport{}}
+	testCases := []*struct {
+		description string
+		url         string
+		opts        []Option
+		check       func(*Client) bool
+		expectError bool
+	}{
+		{
+			description: "Missing credentials",
+			url:         validRawURL,
+			expectError: true,
+		},
+		{
+			description: "Invalid scheme",
+			url:         "ftp://my-tplink-rtr/",
+			opts:        []Option{WithCredentials(user, password)},
+			expectError: true,
+		},
+		{
+			description: "Negative timeout",
+			url:         validRawURL,
+			opts:        []Option{WithCredentials(user, password), WithTimeout(-time.Second)},
+			expectError: true,
+		},
+		{
+			description: "Insecure TLS with a custom RoundTripper",
+			url:         validRawURL,
+			opts:        []Option{WithCredentials(user, password), WithHTTPClient(NewTestClient(nil)), WithInsecureTLS()},
+			expectError: true,
+		},
+		{
+			description: "Defaults",
+			url:         "http://my-tplink-rtr",
+			opts:        []Option{WithCredentials(user, password)},
+			check: func(c *Client) bool {
+				return c.baseURL.String() == validRawURL && c.encodedBasicAuth == validEncodedAuth &&
+					c.httpClient.Timeout == 0 && c.httpClient.Transport == nil && c.retry == nil &&
+					c.logger != nil && c.userAgent == ""
+			},
+		},
+		{
+			description: "Base URL path without trailing slash",
+			url:         "https://my-tplink-rtr/router",
+			opts:        []Option{WithCredentials(user, password)},
+			check:       func(c *Client) bool { return c.baseURL.String() == "https://my-tplink-rtr/router/" },
+		},
+		{
+			description: "HTTP client, logger, user agent and retry policy",
+			url:         validRawURL,
+			opts: []Option{WithCredentials(user, password), WithHTTPClient(hc), WithLogger(testLogger),
+				WithUserAgent("tplink-test"), WithRetry(&RetryPolicy{MaxAttempts: 5})},
+			check: func(c *Client) bool {
+				return c.httpClient == hc && c.logger == testLogger && c.userAgent == "tplink-test" &&
+					c.retry != nil && c.retry.MaxAttempts == 5
+			},
+		},
+		{
+			description: "Timeout and insecure TLS applied to a copy of the HTTP client",
+			url:         validRawURL,
+			opts:        []Option{WithInsecureTLS(), WithTimeout(time.Second), WithHTTPClient(hc), WithCredentials(user, password)},
+			check: func(c *Client) bool {
+				t, ok := c.httpClient.Transport.(*http.Transport)
+				orig := hc.Transport.(*http.Transport)
+				return c.httpClient != hc && hc.Timeout == 0 && c.httpClient.Timeout == time.Second &&
+					ok && t != orig && t.TLSClientConfig != nil && t.TLSClientConfig.InsecureSkipVerify &&
+					(orig.TLSClientConfig == nil || !orig.TLSClientConfig.InsecureSkipVerify)
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		got, err := NewClient(tt.url, tt.opts...)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tNewClient(%s) did not return an expected error", tt.description, tt.url)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\tNewClient(%s) returned an unexpected error: %v", tt.description, tt.url, err)
+			}
+			if !tt.check(got) {
+				t.Fatalf("FAIL: %s\n\tNewClient(%s) returned a Client not configured as expected: %+v",
+					tt.description, tt.url, got)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestWithUserAgent(t *testing.T) {
+	var got string
+	client, _ = NewClient(validRawURL, WithCredentials(user, password), WithUserAgent("tplink-test"),
+		WithHTTPClient(NewTestClient(func(r *http.Request) (*http.Response, error) {
+			got = r.Header.Get("User-Agent")
+			return newPageResponse(r, 200, validStatusPage), nil
+		})))
+	if _, err := client.GetStatus(); err != nil {
+		t.Fatalf("FAIL: %v.GetStatus() returned an unexpected error: %v", client, err)
+	}
+	if got != "tplink-test" {
+		t.Fatalf("FAIL: %v.GetStatus() sent User-Agent %q, want %q", client, got, "tplink-test")
+	}
+	t.Logf("PASS: requests have the configured User-Agent")
+}
@@ -0,0 +1,366 @@
+package archerc9v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	parentalControlPage = "userRpm/ParentCtrlRpm.htm"
+
+	// maxParentalWebsites is the number of website fields on the router's
+	// parental control form.
+	maxParentalWebsites = 8
+)
+
+// weekdayNames holds the abbreviations ParseSchedule accepts and Schedule.String
+// prints, indexed by time.Weekday.
+var weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// Weekdays is a set of days of the week, with bit i set for time.Weekday(i).
+type Weekdays uint8
+
+// EveryDay is the set of all days of the week.
+const EveryDay Weekdays = 1<<7 - 1
+
+// Has reports whether day is in the set.
+func (w Weekdays) Has(day time.Weekday) bool {
+	return w&(1<<uint(day)) != 0
+}
+
+// routerCode returns the router's bitmask for the set, which has bit 0 set for
+// Monday through bit 6 for Sunday.
+func (w Weekdays) routerCode() int {
+	return int(w>>1) | int(w&1)<<6
+}
+
+// weekdaysFromRouterCode is the inverse of Weekdays.routerCode.
+func weekdaysFromRouterCode(code int) Weekdays {
+	return Weekdays(code<<1&int(EveryDay) | code>>6&1)
+}
+
+// String returns the set as a comma separated list of day abbreviations and day
+// ranges, e.g. mon-fri or mon,wed,sat-sun, or everyday if it holds all days.
+func (w Weekdays) String() string {
+	if w&EveryDay == EveryDay {
+		return "everyday"
+	}
+	var parts []string
+	// Walk the days from Monday so that weekends print as sat-sun.
+	for i := 0; i < 7; {
+		day := time.Weekday((i + 1) % 7)
+		if !w.Has(day) {
+			i++
+			continue
+		}
+		j := i
+		for j+1 < 7 && w.Has(time.Weekday((j+2)%7)) {
+			j++
+		}
+		part := weekdayNames[day]
+		if j > i {
+			part += "-" + weekdayNames[(j+1)%7]
+		}
+		parts = append(parts, part)
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
+
+// Schedule is a daily time window on a set of days of the week. Start and End
+// are offsets from midnight in whole minutes and Start must be before End.
+type Schedule struct {
+	Days  Weekdays      `json:"days"`
+	Start time.Duration `json:"start"`
+	End   time.Duration `json:"end"`
+}
+
+// ParseSchedule returns the Schedule described by s, which is a set of days as
+// accepted by ParseWeekdays followed by a time window, e.g. "mon-fri 07:00-21:00"
+// or "sat,sun 09:00-22:30".
+func ParseSchedule(s string) (*Schedule, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("got schedule %q (want days and a time window such as mon-fri 07:00-21:00)", s)
+	}
+
+	days, err := ParseWeekdays(fields[0])
+	if err != nil {
+		return nil, err
+	}
+	sched := &Schedule{Days: days}
+
+	window := strings.SplitN(fields[1], "-", 2)
+	if len(window) != 2 {
+		return nil, fmt.Errorf("got time window %q (want HH:MM-HH:MM)", fields[1])
+	}
+	if sched.Start, err = parseTimeOfDay(window[0]); err != nil {
+		return nil, err
+	}
+	if sched.End, err = parseTimeOfDay(window[1]); err != nil {
+		return nil, err
+	}
+	if err = sched.Validate(); err != nil {
+		return nil, err
+	}
+	return sched, nil
+}
+
+// ParseWeekdays returns the set of days described by s, which is a comma
+// separated list of days or day ranges, e.g. mon-fri or sat,sun. Days are three
+// letter abbreviations (mon, tue, ...) and "everyday" stands for all days.
+// Ranges such as fri-mon wrap around the week.
+func ParseWeekdays(s string) (Weekdays, error) {
+	var days Weekdays
+	for _, part := range strings.Split(strings.ToLower(s), ",") {
+		if part == "everyday" || part == "daily" {
+			days |= EveryDay
+			continue
+		}
+		bounds := strings.SplitN(part, "-", 2)
+		first, err := parseWeekday(bounds[0])
+		if err != nil {
+			return 0, err
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = parseWeekday(bounds[1]); err != nil {
+				return 0, err
+			}
+		}
+		for d := first; ; d = (d + 1) % 7 {
+			days |= 1 << uint(d)
+			if d == last {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	for i, name := range weekdayNames {
+		if s == name {
+			return time.Weekday(i), nil
+		}
+	}
+	return 0, fmt.Errorf("got day %q (want one of %s)", s, strings.Join(weekdayNames, ", "))
+}
+
+// parseTimeOfDay returns the offset from midnight of the time s in the HH:MM
+// format. 24:00 is accepted as the end of the day.
+func parseTimeOfDay(s string) (time.Duration, error) {
+	parts := strings.SplitN(s, ":", 2)
+	if len(parts) == 2 {
+		h, hErr := strconv.Atoi(parts[0])
+		m, mErr := strconv.Atoi(parts[1])
+		if hErr == nil && mErr == nil && len(parts[1]) == 2 && h >= 0 && m >= 0 && m < 60 &&
+			(h < 24 || h == 24 && m == 0) {
+			return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+		}
+	}
+	return 0, fmt.Errorf("got time %q (want HH:MM between 00:00 and 24:00)", s)
+}
+
+// formatTimeOfDay returns the offset from midnight d in the HH:MM format.
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// Validate checks that s describes a schedule the router accepts.
+func (s *Schedule) Validate() error {
+	if s.Days&EveryDay == 0 {
+		return errors.New("got schedule without days (want at least one day)")
+	}
+	for _, d := range []time.Duration{s.Start, s.End} {
+		if d < 0 || d > 24*time.Hour || d%time.Minute != 0 {
+			return fmt.Errorf("got schedule time %s (want whole minutes between 00:00 and 24:00)", d)
+		}
+	}
+	if s.Start >= s.End {
+		return fmt.Errorf("got schedule from %s to %s (want a start before the end)",
+			formatTimeOfDay(s.Start), formatTimeOfDay(s.End))
+	}
+	return nil
+}
+
+// String returns the schedule in the format accepted by ParseSchedule.
+func (s *Schedule) String() string {
+	return s.Days.String() + " " + formatTimeOfDay(s.Start) + "-" + formatTimeOfDay(s.End)
+}
+
+// ParentalControl represents a parental control rule, which lets the node with
+// the given MAC address reach the Internet only during the schedule and, if
+// Websites is not empty, only reach the listed websites. The ID is the position
+// of the rule in the router's list, counting across all of its pages.
+type ParentalControl struct {
+	ID          int      `json:"id"`
+	MacAddress  string   `json:"mac_addr"`
+	Description string   `json:"description"`
+	Websites    []string `json:"websites"`
+	Schedule    Schedule `json:"schedule"`
+	Enabled     bool     `json:"enabled"`
+
+	// pos is where the rule is shown on the router's parental control pages.
+	pos listPosition
+}
+
+// ListParentalControls wraps ListParentalControlsContext using context.Background.
+func (c *Client) ListParentalControls() ([]*ParentalControl, error) {
+	return c.ListParentalControlsContext(context.Background())
+}
+
+// ListParentalControlsContext returns the parental control rules configured on the
+// router or returns an error otherwise.
+func (c *Client) ListParentalControlsContext(ctx context.Context) ([]*ParentalControl, error) {
+	rows, err := c.getListRows(ctx, parentalControlPage, "list parental controls",
+		parseListRows("parentCtrlList", 7, "parental control page"))
+	if err != nil {
+		return nil, err
+	}
+
+	var controls []*ParentalControl
+	for i, row := range rows {
+		days, err := row.intAt(3)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("got invalid days for parental control %d", i))
+		}
+		p := &ParentalControl{
+			ID:          i,
+			MacAddress:  row.strAt(0),
+			Description: row.strAt(1),
+			Schedule:    Schedule{Days: weekdaysFromRouterCode(days)},
+			pos:         row.pos,
+		}
+		if sites := row.strAt(2); sites != "" {
+			p.Websites = strings.Split(sites, " ")
+		}
+		if p.Schedule.Start, err = parseRouterTime(row.strAt(4)); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("got invalid start time for parental control %d", i))
+		}
+		if p.Schedule.End, err = parseRouterTime(row.strAt(5)); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("got invalid end time for parental control %d", i))
+		}
+		if p.Enabled, err = row.boolAt(6); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("got invalid state for parental control %d", i))
+		}
+		controls = append(controls, p)
+	}
+	return controls, nil
+}
+
+// AddParentalControl wraps AddParentalControlContext using context.Background.
+func (c *Client) AddParentalControl(p *ParentalControl) error {
+	return c.AddParentalControlContext(context.Background(), p)
+}
+
+// AddParentalControlContext adds the parental control rule p to the router. An
+// error is returned if the rule is invalid or if the MAC address already has a
+// rule.
+func (c *Client) AddParentalControlContext(ctx context.Context, p *ParentalControl) error {
+	if p == nil {
+		return errors.New("got nil parental control (want non-nil parental control)")
+	}
+	mac, err := NormalizeMAC(p.MacAddress)
+	if err != nil {
+		return err
+	}
+	if err = p.Validate(); err != nil {
+		return errors.Wrap(err, "got invalid parental control")
+	}
+
+	existing, err := c.ListParentalControlsContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "got error listing parental controls to check for duplicates")
+	}
+	for _, e := range existing {
+		if m, _ := NormalizeMAC(e.MacAddress); m == mac {
+			return fmt.Errorf("got MAC address %s that already has parental control %d (want a MAC address without a rule)",
+				mac, e.ID)
+		}
+	}
+
+	q := url.Values{}
+	q.Set("child_mac", mac)
+	q.Set("url_comment", p.Description)
+	for i := 0; i < maxParentalWebsites; i++ {
+		site := ""
+		if i < len(p.Websites) {
+			site = p.Websites[i]
+		}
+		q.Set("url_"+strconv.Itoa(i), site)
+	}
+	q.Set("day", strconv.Itoa(p.Schedule.Days.routerCode()))
+	q.Set("time_start", formatRouterTime(p.Schedule.Start))
+	q.Set("time_end", formatRouterTime(p.Schedule.End))
+	q.Set("State", boolParam(p.Enabled))
+	q.Set("Changed", "0")
+	q.Set("SelIndex", "0")
+	q.Set("Page", "1")
+	q.Set("Save", "Save")
+	_, err = c.getPage(ctx, parentalControlPage, q, "add parental control")
+	return err
+}
+
+// DeleteParentalControl wraps DeleteParentalControlContext using context.Background.
+func (c *Client) DeleteParentalControl(macAddress string) error {
+	return c.DeleteParentalControlContext(context.Background(), macAddress)
+}
+
+// DeleteParentalControlContext deletes the parental control rule for the given MAC
+// address from the router. An error is returned if there is no such rule.
+func (c *Client) DeleteParentalControlContext(ctx context.Context, macAddress string) error {
+	mac, err := NormalizeMAC(macAddress)
+	if err != nil {
+		return err
+	}
+
+	existing, err := c.ListParentalControlsContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "got error listing parental controls to find rule to delete")
+	}
+	for _, e := range existing {
+		if m, _ := NormalizeMAC(e.MacAddress); m == mac {
+			q := url.Values{}
+			e.pos.set(q, "Del")
+			_, err = c.getPage(ctx, parentalControlPage, q, "delete parental control")
+			return err
+		}
+	}
+	return fmt.Errorf("got MAC address %s without a parental control (want a MAC address with a rule)", mac)
+}
+
+// Validate checks that p describes a parental control rule the router accepts.
+// At most 8 websites, given as domain names without spaces, can be allowed.
+func (p *ParentalControl) Validate() error {
+	if _, err := NormalizeMAC(p.MacAddress); err != nil {
+		return err
+	}
+	if len(p.Websites) > maxParentalWebsites {
+		return fmt.Errorf("got %d websites (want at most %d)", len(p.Websites), maxParentalWebsites)
+	}
+	for _, site := range p.Websites {
+		if site == "" || strings.ContainsAny(site, " \t,") {
+			return fmt.Errorf("got website %q (want a domain name such as example.com)", site)
+		}
+	}
+	return p.Schedule.Validate()
+}
+
+// parseRouterTime returns the offset from midnight of the router's HHMM time s.
+func parseRouterTime(s string) (time.Duration, error) {
+	if len(s) != 4 {
+		return 0, fmt.Errorf("got time %q (want HHMM)", s)
+	}
+	return parseTimeOfDay(s[:2] + ":" + s[2:])
+}
+
+// formatRouterTime returns the offset from midnight d in the router's HHMM format.
+func formatRouterTime(d time.Duration) string {
+	return strings.Replace(formatTimeOfDay(d), ":", "", -1)
+}
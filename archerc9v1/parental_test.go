@@ -0,0 +1,278 @@
+package archerc9v1
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var validParentalControlPage = `<script type="text/javascript">
+var parentCtrlList = new Array(
+"12-34-56-AA-BB-CC", "kids tablet", "example.com school.org", 31, "0700", "2100", 1,
+"1A-1A-1A-AA-AA-AA", "", "", 96, "0900", "2400", 0,
+0,0 );
+</script>`
+
+var validParentalControlPage2 = `<script type="text/javascript">
+var parentCtrlList = new Array(
+"3C-3C-3C-CC-CC-CC", "game console", "", 64, "1000", "1200", 1,
+0,0 );
+</script>`
+
+func TestParseSchedule(t *testing.T) {
+	testCases := []*struct {
+		input       string
+		expected    *Schedule
+		expectError bool
+	}{
+		{
+			input: "mon-fri 07:00-21:00",
+			expected: &Schedule{
+				Days:  1<<time.Monday | 1<<time.Tuesday | 1<<time.Wednesday | 1<<time.Thursday | 1<<time.Friday,
+				Start: 7 * time.Hour,
+				End:   21 * time.Hour,
+			},
+		},
+		{
+			input:    "Sat,SUN 09:30-24:00",
+			expected: &Schedule{Days: 1<<time.Saturday | 1<<time.Sunday, Start: 9*time.Hour + 30*time.Minute, End: 24 * time.Hour},
+		},
+		{
+			input:    "fri-mon 00:00-01:00",
+			expected: &Schedule{Days: 1<<time.Friday | 1<<time.Saturday | 1<<time.Sunday | 1<<time.Monday, End: time.Hour},
+		},
+		{
+			input:    "everyday 18:00-20:00",
+			expected: &Schedule{Days: EveryDay, Start: 18 * time.Hour, End: 20 * time.Hour},
+		},
+		{input: "mon-fri", expectError: true},
+		{input: "mon-fry 07:00-21:00", expectError: true},
+		{input: "mon 07:00", expectError: true},
+		{input: "mon 7:0-21:00", expectError: true},
+		{input: "mon 07:00-24:30", expectError: true},
+		{input: "mon 21:00-07:00", expectError: true},
+	}
+
+	for _, tt := range testCases {
+		got, err := ParseSchedule(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tParseSchedule(%q) did not return an expected error", tt.input, tt.input)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\tParseSchedule(%q) returned an unexpected error: %v", tt.input, tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\tParseSchedule(%q) returned %+v, want %+v", tt.input, tt.input, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.input)
+	}
+}
+
+func TestSchedule_String(t *testing.T) {
+	testCases := []*struct {
+		input    string
+		expected string
+	}{
+		{input: "mon-fri 07:00-21:00", expected: "mon-fri 07:00-21:00"},
+		{input: "sun,sat 09:30-24:00", expected: "sat-sun 09:30-24:00"},
+		{input: "mon,wed,thu 00:00-00:01", expected: "mon,wed-thu 00:00-00:01"},
+		{input: "mon-sun 18:00-20:00", expected: "everyday 18:00-20:00"},
+	}
+
+	for _, tt := range testCases {
+		s, err := ParseSchedule(tt.input)
+		if err != nil {
+			t.Fatalf("FAIL: %s\n\tParseSchedule(%q) returned an unexpected error: %v", tt.input, tt.input, err)
+		}
+		if got := s.String(); got != tt.expected {
+			t.Fatalf("FAIL: %s\n\t%+v.String() returned %q, want %q", tt.input, s, got, tt.expected)
+		}
+		t.Logf("PASS: %s", tt.input)
+	}
+}
+
+func TestWeekdays_routerCode(t *testing.T) {
+	for code := 0; code < 128; code++ {
+		if got := weekdaysFromRouterCode(code).routerCode(); got != code {
+			t.Fatalf("FAIL: Round trip\n\tweekdaysFromRouterCode(%d).routerCode() returned %d", code, got)
+		}
+	}
+	if got := Weekdays(1 << time.Sunday).routerCode(); got != 64 {
+		t.Fatalf("FAIL: Sunday\n\tsunday.routerCode() returned %d, want 64", got)
+	}
+	t.Logf("PASS: Round trip")
+}
+
+func TestClient_ListParentalControls(t *testing.T) {
+	client = newPageTestClient(serveListPages(parentalControlPage, validParentalControlPage, validParentalControlPage2))
+	expected := []*ParentalControl{
+		{
+			ID:          0,
+			MacAddress:  "12-34-56-AA-BB-CC",
+			Description: "kids tablet",
+			Websites:    []string{"example.com", "school.org"},
+			Schedule: Schedule{
+				Days:  1<<time.Monday | 1<<time.Tuesday | 1<<time.Wednesday | 1<<time.Thursday | 1<<time.Friday,
+				Start: 7 * time.Hour,
+				End:   21 * time.Hour,
+			},
+			Enabled: true,
+			pos:     listPosition{page: 1, index: 0},
+		},
+		{
+			ID:         1,
+			MacAddress: "1A-1A-1A-AA-AA-AA",
+			Schedule:   Schedule{Days: 1<<time.Saturday | 1<<time.Sunday, Start: 9 * time.Hour, End: 24 * time.Hour},
+			pos:        listPosition{page: 1, index: 1},
+		},
+		{
+			ID:          2,
+			MacAddress:  "3C-3C-3C-CC-CC-CC",
+			Description: "game console",
+			Schedule:    Schedule{Days: 1 << time.Sunday, Start: 10 * time.Hour, End: 12 * time.Hour},
+			Enabled:     true,
+			pos:         listPosition{page: 2, index: 0},
+		},
+	}
+	got, err := client.ListParentalControls()
+	if err != nil {
+		t.Fatalf("FAIL: Valid response\n\t%v.ListParentalControls() returned an unexpected error: %v", client, err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("FAIL: Valid response\n\t%v.ListParentalControls() returned %+v, want %+v", client, got, expected)
+	}
+	t.Logf("PASS: Valid response")
+}
+
+func TestClient_AddParentalControl(t *testing.T) {
+	var saved url.Values
+	client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		if q := r.URL.Query(); len(q) > 1 {
+			saved = q
+		}
+		return serveListPages(parentalControlPage, validParentalControlPage, validParentalControlPage2)(r)
+	})
+	schedule, _ := ParseSchedule("mon-fri 07:00-21:00")
+
+	testCases := []*struct {
+		description string
+		input       *ParentalControl
+		expected    url.Values
+		expectError bool
+	}{
+		{
+			description: "Duplicate MAC address",
+			input:       &ParentalControl{MacAddress: "12:34:56:aa:bb:cc", Schedule: *schedule},
+			expectError: true,
+		},
+		{
+			description: "Duplicate MAC address on second page",
+			input:       &ParentalControl{MacAddress: "3c:3c:3c:cc:cc:cc", Schedule: *schedule},
+			expectError: true,
+		},
+		{
+			description: "Too many websites",
+			input: &ParentalControl{
+				MacAddress: "22-22-22-AA-AA-AA",
+				Websites:   []string{"a", "b", "c", "d", "e", "f", "g", "h", "i"},
+				Schedule:   *schedule,
+			},
+			expectError: true,
+		},
+		{
+			description: "Missing schedule",
+			input:       &ParentalControl{MacAddress: "22-22-22-AA-AA-AA"},
+			expectError: true,
+		},
+		{
+			description: "Valid rule",
+			input: &ParentalControl{
+				MacAddress:  "222222aaaaaa",
+				Description: "laptop",
+				Websites:    []string{"example.com"},
+				Schedule:    *schedule,
+				Enabled:     true,
+			},
+			expected: url.Values{
+				"child_mac":   {"22-22-22-AA-AA-AA"},
+				"url_comment": {"laptop"},
+				"url_0":       {"example.com"},
+				"url_1":       {""},
+				"url_2":       {""},
+				"url_3":       {""},
+				"url_4":       {""},
+				"url_5":       {""},
+				"url_6":       {""},
+				"url_7":       {""},
+				"day":         {"31"},
+				"time_start":  {"0700"},
+				"time_end":    {"2100"},
+				"State":       {"1"},
+				"Changed":     {"0"},
+				"SelIndex":    {"0"},
+				"Page":        {"1"},
+				"Save":        {"Save"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		saved = nil
+		err := client.AddParentalControl(tt.input)
+		if tt.expectError {
+			if err == nil || saved != nil {
+				t.Fatalf("FAIL: %s\n\t%v.AddParentalControl(%+v) returned %v and submitted %v, want an error and nothing submitted",
+					tt.description, client, tt.input, err, saved)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.AddParentalControl(%+v) returned an unexpected error: %v",
+					tt.description, client, tt.input, err)
+			}
+			if !reflect.DeepEqual(saved, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.AddParentalControl(%+v) submitted %v, want %v",
+					tt.description, client, tt.input, saved, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_DeleteParentalControl(t *testing.T) {
+	var saved url.Values
+	client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		if q := r.URL.Query(); len(q) > 1 {
+			saved = q
+		}
+		return serveListPages(parentalControlPage, validParentalControlPage, validParentalControlPage2)(r)
+	})
+	if err := client.DeleteParentalControl("22-22-22-AA-AA-AA"); err == nil {
+		t.Fatalf("FAIL: MAC address without rule\n\t%v.DeleteParentalControl() did not return an expected error", client)
+	}
+	t.Logf("PASS: MAC address without rule")
+
+	if err := client.DeleteParentalControl("1a:1a:1a:aa:aa:aa"); err != nil {
+		t.Fatalf("FAIL: MAC address with rule\n\t%v.DeleteParentalControl() returned an unexpected error: %v", client, err)
+	}
+	expected := url.Values{"Del": {"1"}, "Page": {"1"}}
+	if !reflect.DeepEqual(saved, expected) {
+		t.Fatalf("FAIL: MAC address with rule\n\t%v.DeleteParentalControl() submitted %v, want %v", client, saved, expected)
+	}
+	t.Logf("PASS: MAC address with rule")
+
+	if err := client.DeleteParentalControl("3c3c3ccccccc"); err != nil {
+		t.Fatalf("FAIL: MAC address with rule on second page\n\t%v.DeleteParentalControl() returned an unexpected error: %v",
+			client, err)
+	}
+	expected = url.Values{"Del": {"0"}, "Page": {"2"}}
+	if !reflect.DeepEqual(saved, expected) {
+		t.Fatalf("FAIL: MAC address with rule on second page\n\t%v.DeleteParentalControl() submitted %v, want %v",
+			client, saved, expected)
+	}
+	t.Logf("PASS: MAC address with rule on second page")
+}
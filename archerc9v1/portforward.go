@@ -0,0 +1,280 @@
+package archerc9v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const portForwardsPage = "userRpm/VirtualServerRpm.htm"
+
+// Protocol is the transport protocol a port forwarding rule applies to. The
+// values are the ones used by the router's forms.
+type Protocol int
+
+const (
+	ProtocolAll Protocol = 1
+	ProtocolTCP Protocol = 2
+	ProtocolUDP Protocol = 3
+)
+
+// String returns the name of the protocol as shown by the router.
+func (p Protocol) String() string {
+	switch p {
+	case ProtocolAll:
+		return "ALL"
+	case ProtocolTCP:
+		return "TCP"
+	case ProtocolUDP:
+		return "UDP"
+	}
+	return "Protocol(" + strconv.Itoa(int(p)) + ")"
+}
+
+// ParseProtocol returns the Protocol named by s, which is one of TCP, UDP or
+// ALL in any case.
+func ParseProtocol(s string) (Protocol, error) {
+	for _, p := range []Protocol{ProtocolAll, ProtocolTCP, ProtocolUDP} {
+		if strings.EqualFold(s, p.String()) {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("got protocol %q (want TCP, UDP or ALL)", s)
+}
+
+// PortForward represents a rule of the router's virtual server table, which
+// forwards traffic arriving at the router's service ports to a node on the LAN.
+// InternalPort may only be set for a single service port and is zero if the
+// traffic is forwarded to the same port(s) it arrived at. The ID is the position
+// of the rule in the router's table, counting across all of its pages.
+type PortForward struct {
+	ID                int      `json:"id"`
+	ExternalPortStart int      `json:"external_port_start"`
+	ExternalPortEnd   int      `json:"external_port_end"`
+	InternalIP        string   `json:"internal_ip"`
+	InternalPort      int      `json:"internal_port"`
+	Protocol          Protocol `json:"protocol"`
+	Enabled           bool     `json:"enabled"`
+
+	// pos is where the rule is shown on the router's virtual server pages.
+	pos listPosition
+}
+
+// ListPortForwards wraps ListPortForwardsContext using context.Background.
+func (c *Client) ListPortForwards() ([]*PortForward, error) {
+	return c.ListPortForwardsContext(context.Background())
+}
+
+// ListPortForwardsContext returns the rules of the router's virtual server table or
+// returns an error otherwise.
+func (c *Client) ListPortForwardsContext(ctx context.Context) ([]*PortForward, error) {
+	rows, err := c.getListRows(ctx, portForwardsPage, "list port forwarding rules",
+		parseListRows("virServerListPara", 5, "virtual server page"))
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []*PortForward
+	for i, row := range rows {
+		pf := &PortForward{ID: i, InternalIP: row.strAt(1), pos: row.pos}
+		if pf.ExternalPortStart, pf.ExternalPortEnd, err = ParsePortRange(row.strAt(0)); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("got invalid service port for port forwarding rule %d", i))
+		}
+		if row.strAt(2) != "" {
+			if pf.InternalPort, err = row.intAt(2); err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("got invalid internal port for port forwarding rule %d", i))
+			}
+		}
+		protocol, err := row.intAt(3)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("got invalid protocol for port forwarding rule %d", i))
+		}
+		pf.Protocol = Protocol(protocol)
+		if pf.Enabled, err = row.boolAt(4); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("got invalid state for port forwarding rule %d", i))
+		}
+		rules = append(rules, pf)
+	}
+	return rules, nil
+}
+
+// AddPortForward wraps AddPortForwardContext using context.Background.
+func (c *Client) AddPortForward(pf *PortForward) error {
+	return c.AddPortForwardContext(context.Background(), pf)
+}
+
+// AddPortForwardContext adds a rule to the router's virtual server table. Since the
+// router silently accepts conflicting rules, an error is returned if the rule's
+// service ports overlap with those of an existing rule for the same protocol.
+func (c *Client) AddPortForwardContext(ctx context.Context, pf *PortForward) error {
+	if pf == nil {
+		return errors.New("got nil port forwarding rule (want non-nil rule)")
+	}
+	if err := pf.Validate(); err != nil {
+		return errors.Wrap(err, "got invalid port forwarding rule")
+	}
+
+	existing, err := c.ListPortForwardsContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "got error listing port forwarding rules to check for conflicts")
+	}
+	for _, e := range existing {
+		if pf.Overlaps(e) {
+			return fmt.Errorf("got %s service ports %s overlapping with %s service ports %s of rule %d (want non-overlapping ports)",
+				pf.Protocol, formatPortRange(pf.ExternalPortStart, pf.ExternalPortEnd),
+				e.Protocol, formatPortRange(e.ExternalPortStart, e.ExternalPortEnd), e.ID)
+		}
+	}
+
+	return c.savePortForward(ctx, pf, false, "add port forwarding rule")
+}
+
+// DeletePortForward wraps DeletePortForwardContext using context.Background.
+func (c *Client) DeletePortForward(id int) error {
+	return c.DeletePortForwardContext(context.Background(), id)
+}
+
+// DeletePortForwardContext deletes the rule with the given ID from the router's
+// virtual server table.
+func (c *Client) DeletePortForwardContext(ctx context.Context, id int) error {
+	pf, err := c.getPortForward(ctx, id)
+	if err != nil {
+		return err
+	}
+	q := url.Values{}
+	pf.pos.set(q, "Del")
+	_, err = c.getPage(ctx, portForwardsPage, q, "delete port forwarding rule")
+	return err
+}
+
+// EnablePortForward wraps EnablePortForwardContext using context.Background.
+func (c *Client) EnablePortForward(id int) error {
+	return c.EnablePortForwardContext(context.Background(), id)
+}
+
+// EnablePortForwardContext enables the rule with the given ID in the router's
+// virtual server table.
+func (c *Client) EnablePortForwardContext(ctx context.Context, id int) error {
+	return c.setPortForwardEnabled(ctx, id, true)
+}
+
+// DisablePortForward wraps DisablePortForwardContext using context.Background.
+func (c *Client) DisablePortForward(id int) error {
+	return c.DisablePortForwardContext(context.Background(), id)
+}
+
+// DisablePortForwardContext disables the rule with the given ID in the router's
+// virtual server table.
+func (c *Client) DisablePortForwardContext(ctx context.Context, id int) error {
+	return c.setPortForwardEnabled(ctx, id, false)
+}
+
+func (c *Client) setPortForwardEnabled(ctx context.Context, id int, enabled bool) error {
+	pf, err := c.getPortForward(ctx, id)
+	if err != nil {
+		return err
+	}
+	pf.Enabled = enabled
+	return c.savePortForward(ctx, pf, true, "change port forwarding rule state")
+}
+
+// getPortForward returns the rule with the given ID or an error if there is
+// no such rule.
+func (c *Client) getPortForward(ctx context.Context, id int) (*PortForward, error) {
+	rules, err := c.ListPortForwardsContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error listing port forwarding rules")
+	}
+	for _, pf := range rules {
+		if pf.ID == id {
+			return pf, nil
+		}
+	}
+	return nil, fmt.Errorf("got port forwarding rule ID %d (want one of the %d existing rules)", id, len(rules))
+}
+
+// savePortForward submits pf to the router, either as a new rule or, if changed
+// is true, as a change of the existing rule at pf's position.
+func (c *Client) savePortForward(ctx context.Context, pf *PortForward, changed bool, action string) error {
+	q := url.Values{}
+	q.Set("ExPort", formatPortRange(pf.ExternalPortStart, pf.ExternalPortEnd))
+	q.Set("InPort", "")
+	if pf.InternalPort != 0 {
+		q.Set("InPort", strconv.Itoa(pf.InternalPort))
+	}
+	q.Set("Ip", pf.InternalIP)
+	q.Set("Protocol", strconv.Itoa(int(pf.Protocol)))
+	q.Set("State", boolParam(pf.Enabled))
+	q.Set("Changed", boolParam(changed))
+	if changed {
+		pf.pos.set(q, "SelIndex")
+	} else {
+		q.Set("SelIndex", "0")
+		q.Set("Page", "1")
+	}
+	q.Set("Save", "Save")
+	_, err := c.getPage(ctx, portForwardsPage, q, action)
+	return err
+}
+
+// Validate checks that pf describes a rule the router accepts.
+func (pf *PortForward) Validate() error {
+	for _, port := range []int{pf.ExternalPortStart, pf.ExternalPortEnd} {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("got service port %d (want a port between 1 and 65535)", port)
+		}
+	}
+	if pf.ExternalPortStart > pf.ExternalPortEnd {
+		return fmt.Errorf("got service port range %d-%d (want start <= end)",
+			pf.ExternalPortStart, pf.ExternalPortEnd)
+	}
+	if pf.InternalPort != 0 {
+		if pf.InternalPort < 1 || pf.InternalPort > 65535 {
+			return fmt.Errorf("got internal port %d (want a port between 1 and 65535)", pf.InternalPort)
+		}
+		if pf.ExternalPortStart != pf.ExternalPortEnd {
+			return errors.New("got internal port for a service port range (want an internal port for a single service port only)")
+		}
+	}
+	if _, err := parseIPv4(pf.InternalIP); err != nil {
+		return errors.Wrap(err, "got invalid internal IP address")
+	}
+	if _, err := ParseProtocol(pf.Protocol.String()); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Overlaps reports whether pf and o share at least one service port for a
+// common protocol.
+func (pf *PortForward) Overlaps(o *PortForward) bool {
+	sameProtocol := pf.Protocol == o.Protocol || pf.Protocol == ProtocolAll || o.Protocol == ProtocolAll
+	return sameProtocol &&
+		pf.ExternalPortStart <= o.ExternalPortEnd && o.ExternalPortStart <= pf.ExternalPortEnd
+}
+
+// ParsePortRange parses a single port ("80") or port range ("8000-8010") as
+// used for the service ports of a PortForward.
+func ParsePortRange(s string) (start, end int, err error) {
+	parts := strings.SplitN(s, "-", 2)
+	if start, err = strconv.Atoi(strings.TrimSpace(parts[0])); err != nil {
+		return 0, 0, fmt.Errorf("got port range %q (want PORT or START-END)", s)
+	}
+	end = start
+	if len(parts) == 2 {
+		if end, err = strconv.Atoi(strings.TrimSpace(parts[1])); err != nil {
+			return 0, 0, fmt.Errorf("got port range %q (want PORT or START-END)", s)
+		}
+	}
+	return start, end, nil
+}
+
+func formatPortRange(start, end int) string {
+	if start == end {
+		return strconv.Itoa(start)
+	}
+	return strconv.Itoa(start) + "-" + strconv.Itoa(end)
+}
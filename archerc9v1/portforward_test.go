@@ -0,0 +1,380 @@
+package archerc9v1
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var validPortForwardsPage = `<script type="text/javascript">
+var virServerListPara = new Array(
+"8000-8010", "192.168.0.20", "", 2, 1,
+"22", "192.168.0.21", "2222", 1, 0,
+0,0 );
+</script>`
+
+var validPortForwardsPage2 = `<script type="text/javascript">
+var virServerListPara = new Array(
+"443", "192.168.0.22", "", 2, 1,
+0,0 );
+</script>`
+
+// newPortForwardsTestClient returns a Client for a router serving the given
+// virtual server pages, along with a pointer to the query of the last request
+// that changed the virtual server table.
+func newPortForwardsTestClient(pages ...string) (*Client, *url.Values) {
+	changed := new(url.Values)
+	serve := serveListPages(portForwardsPage, pages...)
+	return newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		q := r.URL.Query()
+		if q.Get("Save") != "" || q.Get("Del") != "" {
+			*changed = q
+		}
+		return serve(r)
+	}), changed
+}
+
+func TestClient_ListPortForwards(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       RoundTripFunc
+		expected    []*PortForward
+		expectError bool
+	}{
+		{
+			description: "404 status code in response",
+			input:       servePages(nil),
+			expectError: true,
+		},
+		{
+			description: "Invalid service port",
+			input: servePages(map[string]string{"/" + portForwardsPage: `
+var virServerListPara = new Array("http", "192.168.0.20", "", 2, 1, 0,0 );`}),
+			expectError: true,
+		},
+		{
+			description: "Valid response",
+			input:       servePages(map[string]string{"/" + portForwardsPage: validPortForwardsPage}),
+			expected: []*PortForward{
+				{
+					ID:                0,
+					ExternalPortStart: 8000,
+					ExternalPortEnd:   8010,
+					InternalIP:        "192.168.0.20",
+					Protocol:          ProtocolTCP,
+					Enabled:           true,
+					pos:               listPosition{page: 1, index: 0},
+				},
+				{
+					ID:                1,
+					ExternalPortStart: 22,
+					ExternalPortEnd:   22,
+					InternalIP:        "192.168.0.21",
+					InternalPort:      2222,
+					Protocol:          ProtocolAll,
+					Enabled:           false,
+					pos:               listPosition{page: 1, index: 1},
+				},
+			},
+		},
+		{
+			description: "Rules on two pages",
+			input:       serveListPages(portForwardsPage, validPortForwardsPage, validPortForwardsPage2),
+			expected: []*PortForward{
+				{
+					ID:                0,
+					ExternalPortStart: 8000,
+					ExternalPortEnd:   8010,
+					InternalIP:        "192.168.0.20",
+					Protocol:          ProtocolTCP,
+					Enabled:           true,
+					pos:               listPosition{page: 1, index: 0},
+				},
+				{
+					ID:                1,
+					ExternalPortStart: 22,
+					ExternalPortEnd:   22,
+					InternalIP:        "192.168.0.21",
+					InternalPort:      2222,
+					Protocol:          ProtocolAll,
+					Enabled:           false,
+					pos:               listPosition{page: 1, index: 1},
+				},
+				{
+					ID:                2,
+					ExternalPortStart: 443,
+					ExternalPortEnd:   443,
+					InternalIP:        "192.168.0.22",
+					Protocol:          ProtocolTCP,
+					Enabled:           true,
+					pos:               listPosition{page: 2, index: 0},
+				},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		client = newPageTestClient(tt.input)
+		got, err := client.ListPortForwards()
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.ListPortForwards() did not return an expected error",
+					tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.ListPortForwards() returned an unexpected error: %v",
+					tt.description, client, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.ListPortForwards() returned %v, want %v",
+					tt.description, client, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_AddPortForward(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       *PortForward
+		expected    url.Values
+		expectError bool
+	}{
+		{
+			description: "Nil rule",
+			input:       nil,
+			expectError: true,
+		},
+		{
+			description: "Invalid rule",
+			input:       &PortForward{ExternalPortStart: 0, ExternalPortEnd: 0, InternalIP: "192.168.0.30", Protocol: ProtocolTCP},
+			expectError: true,
+		},
+		{
+			description: "Overlapping TCP range",
+			input:       &PortForward{ExternalPortStart: 8010, ExternalPortEnd: 8020, InternalIP: "192.168.0.30", Protocol: ProtocolTCP},
+			expectError: true,
+		},
+		{
+			description: "Overlapping with rule for all protocols",
+			input:       &PortForward{ExternalPortStart: 22, ExternalPortEnd: 22, InternalIP: "192.168.0.30", Protocol: ProtocolUDP},
+			expectError: true,
+		},
+		{
+			description: "Same ports for other protocol",
+			input: &PortForward{
+				ExternalPortStart: 8000,
+				ExternalPortEnd:   8000,
+				InternalIP:        "192.168.0.30",
+				InternalPort:      80,
+				Protocol:          ProtocolUDP,
+				Enabled:           true,
+			},
+			expected: url.Values{
+				"ExPort":   {"8000"},
+				"InPort":   {"80"},
+				"Ip":       {"192.168.0.30"},
+				"Protocol": {"3"},
+				"State":    {"1"},
+				"Changed":  {"0"},
+				"SelIndex": {"0"},
+				"Page":     {"1"},
+				"Save":     {"Save"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		var changed *url.Values
+		client, changed = newPortForwardsTestClient(validPortForwardsPage)
+		err := client.AddPortForward(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.AddPortForward(%+v) did not return an expected error",
+					tt.description, client, tt.input)
+			}
+			if *changed != nil {
+				t.Fatalf("FAIL: %s\n\t%v.AddPortForward(%+v) submitted an invalid rule: %v",
+					tt.description, client, tt.input, *changed)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.AddPortForward(%+v) returned an unexpected error: %v",
+					tt.description, client, tt.input, err)
+			}
+			if !reflect.DeepEqual(*changed, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.AddPortForward(%+v) submitted %v, want %v",
+					tt.description, client, tt.input, *changed, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_DeletePortForward(t *testing.T) {
+	var changed *url.Values
+	client, changed = newPortForwardsTestClient(validPortForwardsPage)
+	if err := client.DeletePortForward(2); err == nil {
+		t.Fatalf("FAIL: Unknown rule\n\t%v.DeletePortForward(2) did not return an expected error", client)
+	}
+	t.Logf("PASS: Unknown rule")
+
+	if err := client.DeletePortForward(1); err != nil {
+		t.Fatalf("FAIL: Existing rule\n\t%v.DeletePortForward(1) returned an unexpected error: %v", client, err)
+	}
+	expected := url.Values{"Del": {"1"}, "Page": {"1"}}
+	if !reflect.DeepEqual(*changed, expected) {
+		t.Fatalf("FAIL: Existing rule\n\t%v.DeletePortForward(1) submitted %v, want %v", client, *changed, expected)
+	}
+	t.Logf("PASS: Existing rule")
+}
+
+func TestClient_EnablePortForward(t *testing.T) {
+	var changed *url.Values
+	client, changed = newPortForwardsTestClient(validPortForwardsPage)
+	if err := client.EnablePortForward(1); err != nil {
+		t.Fatalf("FAIL: Disabled rule\n\t%v.EnablePortForward(1) returned an unexpected error: %v", client, err)
+	}
+	expected := url.Values{
+		"ExPort":   {"22"},
+		"InPort":   {"2222"},
+		"Ip":       {"192.168.0.21"},
+		"Protocol": {"1"},
+		"State":    {"1"},
+		"Changed":  {"1"},
+		"SelIndex": {"1"},
+		"Page":     {"1"},
+		"Save":     {"Save"},
+	}
+	if !reflect.DeepEqual(*changed, expected) {
+		t.Fatalf("FAIL: Disabled rule\n\t%v.EnablePortForward(1) submitted %v, want %v", client, *changed, expected)
+	}
+	t.Logf("PASS: Disabled rule")
+
+	if err := client.DisablePortForward(0); err != nil {
+		t.Fatalf("FAIL: Enabled rule\n\t%v.DisablePortForward(0) returned an unexpected error: %v", client, err)
+	}
+	if changed.Get("State") != "0" || changed.Get("SelIndex") != "0" {
+		t.Fatalf("FAIL: Enabled rule\n\t%v.DisablePortForward(0) submitted %v, want State=0 and SelIndex=0",
+			client, *changed)
+	}
+	t.Logf("PASS: Enabled rule")
+}
+
+func TestClient_PortForwardsOnSecondPage(t *testing.T) {
+	var changed *url.Values
+	client, changed = newPortForwardsTestClient(validPortForwardsPage, validPortForwardsPage2)
+	pf := &PortForward{ExternalPortStart: 400, ExternalPortEnd: 500, InternalIP: "192.168.0.30", Protocol: ProtocolTCP}
+	if err := client.AddPortForward(pf); err == nil {
+		t.Fatalf("FAIL: Conflict on second page\n\t%v.AddPortForward(%+v) did not return an expected error", client, pf)
+	}
+	t.Logf("PASS: Conflict on second page")
+
+	if err := client.DeletePortForward(2); err != nil {
+		t.Fatalf("FAIL: Delete on second page\n\t%v.DeletePortForward(2) returned an unexpected error: %v", client, err)
+	}
+	expected := url.Values{"Del": {"0"}, "Page": {"2"}}
+	if !reflect.DeepEqual(*changed, expected) {
+		t.Fatalf("FAIL: Delete on second page\n\t%v.DeletePortForward(2) submitted %v, want %v", client, *changed, expected)
+	}
+	t.Logf("PASS: Delete on second page")
+
+	if err := client.DisablePortForward(2); err != nil {
+		t.Fatalf("FAIL: Disable on second page\n\t%v.DisablePortForward(2) returned an unexpected error: %v", client, err)
+	}
+	if changed.Get("State") != "0" || changed.Get("SelIndex") != "0" || changed.Get("Page") != "2" {
+		t.Fatalf("FAIL: Disable on second page\n\t%v.DisablePortForward(2) submitted %v, want State=0, SelIndex=0 and Page=2",
+			client, *changed)
+	}
+	t.Logf("PASS: Disable on second page")
+}
+
+func TestPortForward_Overlaps(t *testing.T) {
+	testCases := []*struct {
+		description string
+		a, b        *PortForward
+		expected    bool
+	}{
+		{
+			description: "Disjoint ranges",
+			a:           &PortForward{ExternalPortStart: 80, ExternalPortEnd: 89, Protocol: ProtocolTCP},
+			b:           &PortForward{ExternalPortStart: 90, ExternalPortEnd: 99, Protocol: ProtocolTCP},
+			expected:    false,
+		},
+		{
+			description: "Adjacent ranges sharing a port",
+			a:           &PortForward{ExternalPortStart: 80, ExternalPortEnd: 90, Protocol: ProtocolTCP},
+			b:           &PortForward{ExternalPortStart: 90, ExternalPortEnd: 99, Protocol: ProtocolTCP},
+			expected:    true,
+		},
+		{
+			description: "Range containing other range",
+			a:           &PortForward{ExternalPortStart: 1, ExternalPortEnd: 1000, Protocol: ProtocolUDP},
+			b:           &PortForward{ExternalPortStart: 53, ExternalPortEnd: 53, Protocol: ProtocolUDP},
+			expected:    true,
+		},
+		{
+			description: "Different protocols",
+			a:           &PortForward{ExternalPortStart: 53, ExternalPortEnd: 53, Protocol: ProtocolTCP},
+			b:           &PortForward{ExternalPortStart: 53, ExternalPortEnd: 53, Protocol: ProtocolUDP},
+			expected:    false,
+		},
+		{
+			description: "All protocols",
+			a:           &PortForward{ExternalPortStart: 53, ExternalPortEnd: 53, Protocol: ProtocolAll},
+			b:           &PortForward{ExternalPortStart: 53, ExternalPortEnd: 53, Protocol: ProtocolUDP},
+			expected:    true,
+		},
+	}
+
+	for _, tt := range testCases {
+		if got := tt.a.Overlaps(tt.b); got != tt.expected {
+			t.Fatalf("FAIL: %s\n\t%+v.Overlaps(%+v) returned %t, want %t",
+				tt.description, tt.a, tt.b, got, tt.expected)
+		}
+		if got := tt.b.Overlaps(tt.a); got != tt.expected {
+			t.Fatalf("FAIL: %s\n\t%+v.Overlaps(%+v) returned %t, want %t",
+				tt.description, tt.b, tt.a, got, tt.expected)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestParsePortRange(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       string
+		start, end  int
+		expectError bool
+	}{
+		{description: "Single port", input: "80", start: 80, end: 80},
+		{description: "Port range", input: "8000-8010", start: 8000, end: 8010},
+		{description: "Port range with spaces", input: "8000 - 8010", start: 8000, end: 8010},
+		{description: "Empty port", input: "", expectError: true},
+		{description: "Open range", input: "8000-", expectError: true},
+		{description: "Service name", input: "http", expectError: true},
+	}
+
+	for _, tt := range testCases {
+		start, end, err := ParsePortRange(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tParsePortRange(%q) did not return an expected error",
+					tt.description, tt.input)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\tParsePortRange(%q) returned an unexpected error: %v",
+					tt.description, tt.input, err)
+			}
+			if start != tt.start || end != tt.end {
+				t.Fatalf("FAIL: %s\n\tParsePortRange(%q) returned (%d, %d), want (%d, %d)",
+					tt.description, tt.input, start, end, tt.start, tt.end)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
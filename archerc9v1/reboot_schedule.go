@@ -0,0 +1,108 @@
+package archerc9v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const rebootSchedulePage = "userRpm/SysRebootScheduleRpm.htm"
+
+// ErrRebootScheduleUnsupported is returned by GetRebootSchedule and
+// SetRebootSchedule if the router's firmware has no built-in reboot schedule.
+// Check for it with errors.Is.
+var ErrRebootScheduleUnsupported = errors.New("got no reboot schedule page (want firmware that supports scheduled reboots)")
+
+// RebootSchedule is the router's built-in schedule for rebooting itself. While
+// enabled, the router reboots at Time, an offset from midnight in whole minutes,
+// on each of the days.
+type RebootSchedule struct {
+	Enabled bool          `json:"enabled"`
+	Days    Weekdays      `json:"days"`
+	Time    time.Duration `json:"time"`
+}
+
+// String describes the schedule, e.g. "mon-fri 04:00" or "disabled".
+func (s *RebootSchedule) String() string {
+	if !s.Enabled {
+		return "disabled"
+	}
+	return s.Days.String() + " " + formatTimeOfDay(s.Time)
+}
+
+// Validate checks that s describes a reboot schedule the router accepts.
+func (s *RebootSchedule) Validate() error {
+	if s.Enabled && s.Days&EveryDay == 0 {
+		return errors.New("got reboot schedule without days (want at least one day)")
+	}
+	if s.Time < 0 || s.Time >= 24*time.Hour || s.Time%time.Minute != 0 {
+		return fmt.Errorf("got reboot time %s (want a time of day in whole minutes)", s.Time)
+	}
+	return nil
+}
+
+// GetRebootSchedule wraps GetRebootScheduleContext using context.Background.
+func (c *Client) GetRebootSchedule() (*RebootSchedule, error) {
+	return c.GetRebootScheduleContext(context.Background())
+}
+
+// GetRebootScheduleContext returns the router's built-in reboot schedule or returns
+// an error otherwise. The error is ErrRebootScheduleUnsupported if the firmware
+// has no reboot schedule.
+func (c *Client) GetRebootScheduleContext(ctx context.Context) (*RebootSchedule, error) {
+	data, err := c.readPage(ctx, rebootSchedulePage, nil, "get reboot schedule")
+	if isPageNotFound(err) {
+		return nil, ErrRebootScheduleUnsupported
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	para, err := parseJSArray(data, "rebootSchedulePara")
+	if err != nil {
+		return nil, errors.Wrap(err, "got error parsing reboot schedule page")
+	}
+	s := &RebootSchedule{}
+	if s.Enabled, err = para.boolAt(0); err != nil {
+		return nil, errors.Wrap(err, "got invalid reboot schedule state")
+	}
+	code, err := para.intAt(1)
+	if err != nil {
+		return nil, errors.Wrap(err, "got invalid reboot schedule days")
+	}
+	s.Days = weekdaysFromRouterCode(code)
+	if s.Time, err = parseRouterTime(para.strAt(2)); err != nil {
+		return nil, errors.Wrap(err, "got invalid reboot schedule time")
+	}
+	return s, nil
+}
+
+// SetRebootSchedule wraps SetRebootScheduleContext using context.Background.
+func (c *Client) SetRebootSchedule(s *RebootSchedule) error {
+	return c.SetRebootScheduleContext(context.Background(), s)
+}
+
+// SetRebootScheduleContext changes the router's built-in reboot schedule to s or
+// returns an error otherwise. The error is ErrRebootScheduleUnsupported if the
+// firmware has no reboot schedule.
+func (c *Client) SetRebootScheduleContext(ctx context.Context, s *RebootSchedule) error {
+	if s == nil {
+		return errors.New("got nil reboot schedule (want non-nil schedule)")
+	}
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	q := url.Values{}
+	q.Set("enable", boolParam(s.Enabled))
+	q.Set("day", strconv.Itoa(s.Days.routerCode()))
+	q.Set("time", formatRouterTime(s.Time))
+	q.Set("Save", "Save")
+	_, err := c.getPage(ctx, rebootSchedulePage, q, "set reboot schedule")
+	if isPageNotFound(err) {
+		return ErrRebootScheduleUnsupported
+	}
+	return err
+}
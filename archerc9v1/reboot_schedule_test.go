@@ -0,0 +1,135 @@
+package archerc9v1
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var validRebootSchedulePage = `<script type="text/javascript">
+var rebootSchedulePara = new Array(
+1,
+31,
+"0415",
+0,0 );
+</script>`
+
+func TestClient_GetRebootSchedule(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       RoundTripFunc
+		expected    *RebootSchedule
+		expectError bool
+	}{
+		{
+			description: "Firmware without reboot schedule",
+			input:       servePages(nil),
+			expectError: true,
+		},
+		{
+			description: "Invalid time",
+			input: servePages(map[string]string{
+				"/" + rebootSchedulePage: strings.Replace(validRebootSchedulePage, "0415", "4:15", 1),
+			}),
+			expectError: true,
+		},
+		{
+			description: "Valid reboot schedule",
+			input:       servePages(map[string]string{"/" + rebootSchedulePage: validRebootSchedulePage}),
+			expected: &RebootSchedule{
+				Enabled: true,
+				Days:    1<<time.Monday | 1<<time.Tuesday | 1<<time.Wednesday | 1<<time.Thursday | 1<<time.Friday,
+				Time:    4*time.Hour + 15*time.Minute,
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		client = newPageTestClient(tt.input)
+		got, err := client.GetRebootSchedule()
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetRebootSchedule() did not return an expected error", tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetRebootSchedule() returned an unexpected error: %v", tt.description, client, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.GetRebootSchedule() returned %+v, want %+v", tt.description, client, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+
+	client = newPageTestClient(servePages(nil))
+	if _, err := client.GetRebootSchedule(); !errors.Is(err, ErrRebootScheduleUnsupported) {
+		t.Fatalf("FAIL: Unsupported firmware\n\t%v.GetRebootSchedule() returned %v, want %v",
+			client, err, ErrRebootScheduleUnsupported)
+	}
+}
+
+func TestClient_SetRebootSchedule(t *testing.T) {
+	var saved url.Values
+	client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		saved = r.URL.Query()
+		return servePages(map[string]string{"/" + rebootSchedulePage: validRebootSchedulePage})(r)
+	})
+
+	testCases := []*struct {
+		description string
+		input       *RebootSchedule
+		expected    url.Values
+		expectError bool
+	}{
+		{
+			description: "Nil schedule",
+			input:       nil,
+			expectError: true,
+		},
+		{
+			description: "Enabled without days",
+			input:       &RebootSchedule{Enabled: true, Time: 4 * time.Hour},
+			expectError: true,
+		},
+		{
+			description: "Time past midnight",
+			input:       &RebootSchedule{Enabled: true, Days: EveryDay, Time: 24 * time.Hour},
+			expectError: true,
+		},
+		{
+			description: "Weekend reboot",
+			input:       &RebootSchedule{Enabled: true, Days: 1<<time.Saturday | 1<<time.Sunday, Time: 3*time.Hour + 30*time.Minute},
+			expected:    url.Values{"enable": {"1"}, "day": {"96"}, "time": {"0330"}, "Save": {"Save"}},
+		},
+		{
+			description: "Disabled",
+			input:       &RebootSchedule{},
+			expected:    url.Values{"enable": {"0"}, "day": {"0"}, "time": {"0000"}, "Save": {"Save"}},
+		},
+	}
+
+	for _, tt := range testCases {
+		saved = nil
+		err := client.SetRebootSchedule(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.SetRebootSchedule(%+v) did not return an expected error", tt.description, client, tt.input)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.SetRebootSchedule(%+v) returned an unexpected error: %v",
+					tt.description, client, tt.input, err)
+			}
+			if !reflect.DeepEqual(saved, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.SetRebootSchedule(%+v) submitted %v, want %v",
+					tt.description, client, tt.input, saved, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
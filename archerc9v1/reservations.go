@@ -0,0 +1,150 @@
+package archerc9v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/url"
+)
+
+const reservationsPage = "userRpm/FixMapCfgRpm.htm"
+
+// Reservation represents a static DHCP address reservation, which makes the
+// router always hand out the same IP address to the node with the given MAC
+// address. The ID is the position of the reservation in the router's list,
+// counting across all of its pages.
+type Reservation struct {
+	ID         int    `json:"id"`
+	MacAddress string `json:"mac_addr"`
+	IPAddress  string `json:"ip_addr"`
+	Enabled    bool   `json:"enabled"`
+
+	// pos is where the reservation is shown on the router's reservation pages.
+	pos listPosition
+}
+
+// ListReservations wraps ListReservationsContext using context.Background.
+func (c *Client) ListReservations() ([]*Reservation, error) {
+	return c.ListReservationsContext(context.Background())
+}
+
+// ListReservationsContext returns the static DHCP address reservations configured
+// on the router or returns an error otherwise.
+func (c *Client) ListReservationsContext(ctx context.Context) ([]*Reservation, error) {
+	rows, err := c.getListRows(ctx, reservationsPage, "list DHCP reservations",
+		parseListRows("dhcpList", 3, "DHCP reservations page"))
+	if err != nil {
+		return nil, err
+	}
+
+	var reservations []*Reservation
+	for i, row := range rows {
+		enabled, err := row.boolAt(2)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("got invalid state for DHCP reservation %d", i))
+		}
+		reservations = append(reservations, &Reservation{
+			ID:         i,
+			MacAddress: row.strAt(0),
+			IPAddress:  row.strAt(1),
+			Enabled:    enabled,
+			pos:        row.pos,
+		})
+	}
+	return reservations, nil
+}
+
+// AddReservation wraps AddReservationContext using context.Background.
+func (c *Client) AddReservation(r *Reservation) error {
+	return c.AddReservationContext(context.Background(), r)
+}
+
+// AddReservationContext adds a static DHCP address reservation to the router. An
+// error is returned if the MAC address already has a reservation, if the IP address
+// is already reserved for another node, or if the IP address lies outside of the
+// DHCP server's address pool.
+func (c *Client) AddReservationContext(ctx context.Context, r *Reservation) error {
+	if r == nil {
+		return errors.New("got nil reservation (want non-nil reservation)")
+	}
+	mac, err := NormalizeMAC(r.MacAddress)
+	if err != nil {
+		return err
+	}
+	ip, err := parseIPv4(r.IPAddress)
+	if err != nil {
+		return errors.Wrap(err, "got invalid reserved IP address")
+	}
+
+	dhcp, err := c.GetDHCPSettingsContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "got error getting DHCP address pool to validate reservation")
+	}
+	start, err := parseIPv4(dhcp.StartIP)
+	if err != nil {
+		return errors.Wrap(err, "got invalid DHCP pool start address from router")
+	}
+	end, err := parseIPv4(dhcp.EndIP)
+	if err != nil {
+		return errors.Wrap(err, "got invalid DHCP pool end address from router")
+	}
+	if ipv4ToUint32(ip) < ipv4ToUint32(start) || ipv4ToUint32(ip) > ipv4ToUint32(end) {
+		return fmt.Errorf("got reserved IP address %s outside of the DHCP address pool (want an address in %s-%s)",
+			r.IPAddress, dhcp.StartIP, dhcp.EndIP)
+	}
+
+	existing, err := c.ListReservationsContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "got error listing DHCP reservations to check for duplicates")
+	}
+	for _, e := range existing {
+		if m, _ := NormalizeMAC(e.MacAddress); m == mac {
+			return fmt.Errorf("got MAC address %s that already has a reservation for %s (want a MAC address without a reservation)",
+				mac, e.IPAddress)
+		}
+		if e.IPAddress == ip.String() {
+			return fmt.Errorf("got IP address %s that is already reserved for %s (want an unreserved IP address)",
+				e.IPAddress, e.MacAddress)
+		}
+	}
+
+	q := url.Values{}
+	q.Set("Mac", mac)
+	q.Set("Ip", ip.String())
+	q.Set("State", boolParam(r.Enabled))
+	q.Set("Changed", "0")
+	q.Set("SelIndex", "0")
+	q.Set("Page", "1")
+	q.Set("Save", "Save")
+	_, err = c.getPage(ctx, reservationsPage, q, "add DHCP reservation")
+	return err
+}
+
+// DeleteReservation wraps DeleteReservationContext using context.Background.
+func (c *Client) DeleteReservation(macAddress string) error {
+	return c.DeleteReservationContext(context.Background(), macAddress)
+}
+
+// DeleteReservationContext deletes the static DHCP address reservation for the
+// given MAC address from the router. An error is returned if there is no such
+// reservation.
+func (c *Client) DeleteReservationContext(ctx context.Context, macAddress string) error {
+	mac, err := NormalizeMAC(macAddress)
+	if err != nil {
+		return err
+	}
+
+	existing, err := c.ListReservationsContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "got error listing DHCP reservations to find reservation to delete")
+	}
+	for _, e := range existing {
+		if m, _ := NormalizeMAC(e.MacAddress); m == mac {
+			q := url.Values{}
+			e.pos.set(q, "Del")
+			_, err = c.getPage(ctx, reservationsPage, q, "delete DHCP reservation")
+			return err
+		}
+	}
+	return fmt.Errorf("got MAC address %s without a reservation (want a reserved MAC address)", mac)
+}
@@ -0,0 +1,205 @@
+package archerc9v1
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var validReservationsPage = `<script type="text/javascript">
+var dhcpList = new Array(
+"12-34-56-AA-BB-CC", "192.168.0.150", 1,
+"1A-1A-1A-AA-AA-AA", "192.168.0.151", 0,
+0,0 );
+</script>`
+
+var validReservationsPage2 = `<script type="text/javascript">
+var dhcpList = new Array(
+"3C-3C-3C-CC-CC-CC", "192.168.0.152", 1,
+0,0 );
+</script>`
+
+// newReservationsTestClient returns a Client for a router serving the DHCP page
+// and two reservations pages, along with a pointer to the query of the last
+// request that changed the reservations.
+func newReservationsTestClient() (*Client, *url.Values) {
+	changed := new(url.Values)
+	dhcp := servePages(map[string]string{"/" + dhcpSettingsPage: validDHCPPage})
+	reservations := serveListPages(reservationsPage, validReservationsPage, validReservationsPage2)
+	return newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/"+reservationsPage {
+			return dhcp(r)
+		}
+		q := r.URL.Query()
+		if q.Get("Save") != "" || q.Get("Del") != "" {
+			*changed = q
+		}
+		return reservations(r)
+	}), changed
+}
+
+func TestClient_ListReservations(t *testing.T) {
+	client, _ = newReservationsTestClient()
+	expected := []*Reservation{
+		{ID: 0, MacAddress: "12-34-56-AA-BB-CC", IPAddress: "192.168.0.150", Enabled: true,
+			pos: listPosition{page: 1, index: 0}},
+		{ID: 1, MacAddress: "1A-1A-1A-AA-AA-AA", IPAddress: "192.168.0.151", Enabled: false,
+			pos: listPosition{page: 1, index: 1}},
+		{ID: 2, MacAddress: "3C-3C-3C-CC-CC-CC", IPAddress: "192.168.0.152", Enabled: true,
+			pos: listPosition{page: 2, index: 0}},
+	}
+	got, err := client.ListReservations()
+	if err != nil {
+		t.Fatalf("FAIL: Valid response\n\t%v.ListReservations() returned an unexpected error: %v",
+			client, err)
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("FAIL: Valid response\n\t%v.ListReservations() returned %v, want %v",
+			client, got, expected)
+	}
+	t.Logf("PASS: Valid response")
+
+	client = newPageTestClient(servePages(map[string]string{"/" + reservationsPage: "<html></html>"}))
+	if _, err = client.ListReservations(); err == nil {
+		t.Fatalf("FAIL: Page without reservations\n\t%v.ListReservations() did not return an expected error",
+			client)
+	}
+	t.Logf("PASS: Page without reservations")
+}
+
+func TestClient_AddReservation(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       *Reservation
+		expected    url.Values
+		expectError bool
+	}{
+		{
+			description: "Nil reservation",
+			input:       nil,
+			expectError: true,
+		},
+		{
+			description: "Invalid MAC address",
+			input:       &Reservation{MacAddress: "not-a-mac", IPAddress: "192.168.0.160"},
+			expectError: true,
+		},
+		{
+			description: "Duplicate MAC address",
+			input:       &Reservation{MacAddress: "12:34:56:aa:bb:cc", IPAddress: "192.168.0.160"},
+			expectError: true,
+		},
+		{
+			description: "Duplicate IP address",
+			input:       &Reservation{MacAddress: "22-22-22-22-22-22", IPAddress: "192.168.0.151"},
+			expectError: true,
+		},
+		{
+			description: "Duplicate MAC address on second page",
+			input:       &Reservation{MacAddress: "3c:3c:3c:cc:cc:cc", IPAddress: "192.168.0.160"},
+			expectError: true,
+		},
+		{
+			description: "Duplicate IP address on second page",
+			input:       &Reservation{MacAddress: "22-22-22-22-22-22", IPAddress: "192.168.0.152"},
+			expectError: true,
+		},
+		{
+			description: "IP address outside of DHCP pool",
+			input:       &Reservation{MacAddress: "22-22-22-22-22-22", IPAddress: "192.168.0.20"},
+			expectError: true,
+		},
+		{
+			description: "Valid reservation",
+			input:       &Reservation{MacAddress: "22:22:22:aa:aa:aa", IPAddress: "192.168.0.160", Enabled: true},
+			expected: url.Values{
+				"Mac":      {"22-22-22-AA-AA-AA"},
+				"Ip":       {"192.168.0.160"},
+				"State":    {"1"},
+				"Changed":  {"0"},
+				"SelIndex": {"0"},
+				"Page":     {"1"},
+				"Save":     {"Save"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		var changed *url.Values
+		client, changed = newReservationsTestClient()
+		err := client.AddReservation(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.AddReservation(%+v) did not return an expected error",
+					tt.description, client, tt.input)
+			}
+			if *changed != nil {
+				t.Fatalf("FAIL: %s\n\t%v.AddReservation(%+v) submitted an invalid reservation: %v",
+					tt.description, client, tt.input, *changed)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.AddReservation(%+v) returned an unexpected error: %v",
+					tt.description, client, tt.input, err)
+			}
+			if !reflect.DeepEqual(*changed, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.AddReservation(%+v) submitted %v, want %v",
+					tt.description, client, tt.input, *changed, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_DeleteReservation(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       string
+		expected    url.Values
+		expectError bool
+	}{
+		{
+			description: "Invalid MAC address",
+			input:       "not-a-mac",
+			expectError: true,
+		},
+		{
+			description: "MAC address without reservation",
+			input:       "22-22-22-22-22-22",
+			expectError: true,
+		},
+		{
+			description: "Reserved MAC address",
+			input:       "1a:1a:1a:aa:aa:aa",
+			expected:    url.Values{"Del": {"1"}, "Page": {"1"}},
+		},
+		{
+			description: "Reserved MAC address on second page",
+			input:       "3c-3c-3c-cc-cc-cc",
+			expected:    url.Values{"Del": {"0"}, "Page": {"2"}},
+		},
+	}
+
+	for _, tt := range testCases {
+		var changed *url.Values
+		client, changed = newReservationsTestClient()
+		err := client.DeleteReservation(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.DeleteReservation(%s) did not return an expected error",
+					tt.description, client, tt.input)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.DeleteReservation(%s) returned an unexpected error: %v",
+					tt.description, client, tt.input, err)
+			}
+			if !reflect.DeepEqual(*changed, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.DeleteReservation(%s) submitted %v, want %v",
+					tt.description, client, tt.input, *changed, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
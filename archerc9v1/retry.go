@@ -0,0 +1,157 @@
+package archerc9v1
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy makes a Client send a request again if the router fails it with
+// a transient error, e.g. because it is busy. Only requests that read from the
+// router are retried. Since the router applies changes through the same kind of
+// requests it serves its pages with, the Client's methods mark the requests that
+// only read, e.g. those of GetStatus, GetWiredConnections and the List methods.
+// Actions like Reboot, saving settings and uploads are therefore never retried.
+// Except for Jitter, the zero values of the fields are replaced by the defaults
+// noted below.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times a request is sent (default 3).
+	MaxAttempts int
+	// MinBackoff is the time to wait before the first retry (default 500ms). It
+	// doubles with each further retry, up to MaxBackoff (default 10s).
+	MinBackoff, MaxBackoff time.Duration
+	// Jitter is the fraction, between 0 and 1, by which each backoff is randomly
+	// shortened so that clients do not retry in lockstep. 0 turns jitter off and
+	// a negative value selects the default of 0.5.
+	Jitter float64
+	// RetryStatus reports whether a response with the status code is retried
+	// (default: every 5xx status code except 501 Not Implemented).
+	RetryStatus func(code int) bool
+	// RetryError reports whether a request that failed with the error is
+	// retried (default: every error). Requests whose context is done are
+	// never retried.
+	RetryError func(err error) bool
+}
+
+// SetRetryPolicy makes c retry failed requests as described by p. A nil p turns
+// retries off, which is the default.
+func (c *Client) SetRetryPolicy(p *RetryPolicy) {
+	if p == nil {
+		c.retry = nil
+		return
+	}
+	r := *p
+	if r.MaxAttempts <= 0 {
+		r.MaxAttempts = 3
+	}
+	if r.MinBackoff <= 0 {
+		r.MinBackoff = 500 * time.Millisecond
+	}
+	if r.MaxBackoff <= 0 {
+		r.MaxBackoff = 10 * time.Second
+	}
+	if r.MaxBackoff < r.MinBackoff {
+		r.MaxBackoff = r.MinBackoff
+	}
+	if r.Jitter < 0 {
+		r.Jitter = 0.5
+	}
+	if r.Jitter > 1 {
+		r.Jitter = 1
+	}
+	if r.RetryStatus == nil {
+		r.RetryStatus = func(code int) bool {
+			return code/100 == 5 && code != http.StatusNotImplemented
+		}
+	}
+	if r.RetryError == nil {
+		r.RetryError = func(error) bool { return true }
+	}
+	c.retry = &r
+}
+
+// backoff returns the time to wait before the given retry, counting from 1.
+func (p *RetryPolicy) backoff(retry int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < retry && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	if d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d - time.Duration(rand.Float64()*p.Jitter*float64(d))
+}
+
+// do sends req with c's HTTP client up to MaxAttempts times until it neither
+// fails with a retryable error nor gets a retryable status code, and returns
+// the last response or error.
+func (p *RetryPolicy) do(c *Client, req *http.Request) (*http.Response, error) {
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(req)
+		if attempt >= p.MaxAttempts || req.Context().Err() != nil {
+			return resp, err
+		}
+		if err == nil && !p.RetryStatus(resp.StatusCode) {
+			return resp, nil
+		}
+		if err != nil && !p.RetryError(err) {
+			return resp, err
+		}
+
+		next, nextErr := rewind(req)
+		if nextErr != nil {
+			return resp, err
+		}
+		wait := p.backoff(attempt)
+		keyvals := []interface{}{"method", req.Method, "url", redactURL(req.URL), "attempt", attempt,
+			"max_attempts", p.MaxAttempts, "backoff", wait}
+		if err == nil {
+			keyvals = append(keyvals, "status", resp.StatusCode)
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		} else {
+			keyvals = append(keyvals, "error", err)
+		}
+		c.logger.Warn("retrying request", keyvals...)
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, err
+		}
+		req = next
+	}
+}
+
+// rewind returns a copy of req whose body can be sent again.
+func rewind(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+	if req.Body == nil || req.Body == http.NoBody {
+		return next, nil
+	}
+	if req.GetBody == nil {
+		return nil, errors.New("got request body that cannot be sent again")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	next.Body = body
+	return next, nil
+}
+
+// isRead reports whether req was marked with asRead as only reading from the
+// router and may therefore be sent again.
+func isRead(req *http.Request) bool {
+	read, _ := req.Context().Value(readKey{}).(bool)
+	return read
+}
+
+type readKey struct{}
+
+// asRead returns a copy of ctx that marks the requests made with it as only
+// reading from the router, which lets a RetryPolicy send them again.
+func asRead(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readKey{}, true)
+}
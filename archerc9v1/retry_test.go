@@ -0,0 +1,339 @@
+package archerc9v1
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// flaky returns a RoundTripFunc that fails the first failures requests with
+// err, or with statusCode if err is nil, and then responds with the page body.
+// The number of requests and their bodies are recorded in calls and bodies.
+func flaky(failures, statusCode int, err error, calls *int, bodies *[]string) RoundTripFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		*calls++
+		if r.Body != nil {
+			data, _ := ioutil.ReadAll(r.Body)
+			*bodies = append(*bodies, string(data))
+		}
+		if *calls <= failures {
+			if err != nil {
+				return nil, err
+			}
+			return newPageResponse(r, statusCode, ""), nil
+		}
+		return newPageResponse(r, 200, "body"), nil
+	}
+}
+
+func TestClient_Do_Retry(t *testing.T) {
+	errReset := errors.New("connection reset by peer")
+	testCases := []*struct {
+		description    string
+		policy         *RetryPolicy
+		read           bool
+		method, urlStr string
+		body           map[string]string
+		failures       int
+		statusCode     int
+		err            error
+		expectedStatus int
+		expectedCalls  int
+		expectError    bool
+	}{
+		{
+			description:    "No retry policy",
+			method:         "GET",
+			urlStr:         "userRpm/StatusRpm.htm",
+			failures:       1,
+			statusCode:     503,
+			expectedStatus: 503,
+			expectedCalls:  1,
+		},
+		{
+			description:    "Read retried after 503",
+			policy:         &RetryPolicy{},
+			read:           true,
+			method:         "GET",
+			urlStr:         "userRpm/StatusRpm.htm",
+			failures:       1,
+			statusCode:     503,
+			expectedStatus: 200,
+			expectedCalls:  2,
+		},
+		{
+			description:    "Read with page number retried after dropped connections",
+			policy:         &RetryPolicy{},
+			read:           true,
+			method:         "GET",
+			urlStr:         "userRpm/MacFilterRpm.htm?Page=1",
+			failures:       2,
+			err:            errReset,
+			expectedStatus: 200,
+			expectedCalls:  3,
+		},
+		{
+			description:    "Read with body retried with the same body",
+			policy:         &RetryPolicy{},
+			read:           true,
+			method:         "GET",
+			urlStr:         "userRpm/StatusRpm.htm",
+			body:           map[string]string{"a": "1"},
+			failures:       2,
+			statusCode:     500,
+			expectedStatus: 200,
+			expectedCalls:  3,
+		},
+		{
+			description:    "Last response returned after max attempts",
+			policy:         &RetryPolicy{MaxAttempts: 4},
+			read:           true,
+			method:         "GET",
+			urlStr:         "userRpm/StatusRpm.htm",
+			failures:       5,
+			statusCode:     502,
+			expectedStatus: 502,
+			expectedCalls:  4,
+		},
+		{
+			description:   "Last error returned after max attempts",
+			policy:        &RetryPolicy{MaxAttempts: 2},
+			read:          true,
+			method:        "GET",
+			urlStr:        "userRpm/StatusRpm.htm",
+			failures:      2,
+			err:           errReset,
+			expectedCalls: 2,
+			expectError:   true,
+		},
+		{
+			description:    "Status code not retryable",
+			policy:         &RetryPolicy{},
+			read:           true,
+			method:         "GET",
+			urlStr:         "userRpm/StatusRpm.htm",
+			failures:       1,
+			statusCode:     404,
+			expectedStatus: 404,
+			expectedCalls:  1,
+		},
+		{
+			description:    "501 not retried by default",
+			policy:         &RetryPolicy{},
+			read:           true,
+			method:         "GET",
+			urlStr:         "userRpm/StatusRpm.htm",
+			failures:       1,
+			statusCode:     501,
+			expectedStatus: 501,
+			expectedCalls:  1,
+		},
+		{
+			description:    "Custom retryable status code",
+			policy:         &RetryPolicy{RetryStatus: func(code int) bool { return code == 429 }},
+			read:           true,
+			method:         "GET",
+			urlStr:         "userRpm/StatusRpm.htm",
+			failures:       1,
+			statusCode:     429,
+			expectedStatus: 200,
+			expectedCalls:  2,
+		},
+		{
+			description:   "Error not retryable",
+			policy:        &RetryPolicy{RetryError: func(err error) bool { return !errors.Is(err, errReset) }},
+			read:          true,
+			method:        "GET",
+			urlStr:        "userRpm/StatusRpm.htm",
+			failures:      1,
+			err:           errReset,
+			expectedCalls: 1,
+			expectError:   true,
+		},
+		{
+			description:    "Reboot never retried",
+			policy:         &RetryPolicy{},
+			method:         "GET",
+			urlStr:         "userRpm/SysRebootRpm.htm?Reboot=Reboot",
+			failures:       1,
+			statusCode:     503,
+			expectedStatus: 503,
+			expectedCalls:  1,
+		},
+		{
+			description:    "Save action never retried",
+			policy:         &RetryPolicy{},
+			method:         "GET",
+			urlStr:         "userRpm/WlanNetworkRpm.htm?ssid1=foo&Save=Save",
+			failures:       1,
+			statusCode:     503,
+			expectedStatus: 503,
+			expectedCalls:  1,
+		},
+		{
+			description:    "Unmarked read never retried",
+			policy:         &RetryPolicy{},
+			method:         "GET",
+			urlStr:         "userRpm/StatusRpm.htm",
+			failures:       1,
+			statusCode:     503,
+			expectedStatus: 503,
+			expectedCalls:  1,
+		},
+		{
+			description:    "POST read retried after 503",
+			policy:         &RetryPolicy{},
+			read:           true,
+			method:         "POST",
+			urlStr:         wiredConnectionsPage,
+			failures:       1,
+			statusCode:     503,
+			expectedStatus: 200,
+			expectedCalls:  2,
+		},
+		{
+			description:   "POST never retried",
+			policy:        &RetryPolicy{},
+			method:        "POST",
+			urlStr:        "incoming/Firmware.htm",
+			failures:      1,
+			err:           errReset,
+			expectedCalls: 1,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range testCases {
+		var calls int
+		var bodies []string
+		client = newPageTestClient(flaky(tt.failures, tt.statusCode, tt.err, &calls, &bodies))
+		if tt.policy != nil {
+			tt.policy.MinBackoff = time.Millisecond
+			client.SetRetryPolicy(tt.policy)
+		}
+		ctx := context.Background()
+		if tt.read {
+			ctx = asRead(ctx)
+		}
+		req, err := client.NewRequestWithContext(ctx, tt.method, tt.urlStr, tt.body)
+		if err != nil {
+			t.Fatalf("FAIL: %s\n\t%v.NewRequest() returned an unexpected error: %v", tt.description, client, err)
+		}
+		resp, err := client.Do(req)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.Do() did not return an expected error", tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.Do() returned an unexpected error: %v", tt.description, client, err)
+			}
+			if resp.StatusCode != tt.expectedStatus {
+				t.Fatalf("FAIL: %s\n\t%v.Do() got status code %d, want %d", tt.description, client, resp.StatusCode, tt.expectedStatus)
+			}
+		}
+		if calls != tt.expectedCalls {
+			t.Fatalf("FAIL: %s\n\t%v.Do() sent the request %d time(s), want %d", tt.description, client, calls, tt.expectedCalls)
+		}
+		for _, b := range bodies {
+			if b != bodies[0] {
+				t.Fatalf("FAIL: %s\n\t%v.Do() sent bodies %q, want the same body each time", tt.description, client, bodies)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_Do_RetryCanceled(t *testing.T) {
+	var calls int
+	var bodies []string
+	client = newPageTestClient(flaky(5, 503, nil, &calls, &bodies))
+	client.SetRetryPolicy(&RetryPolicy{MaxAttempts: 5, MinBackoff: time.Hour})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	req, err := client.NewRequestWithContext(asRead(ctx), "GET", "userRpm/StatusRpm.htm", nil)
+	if err != nil {
+		t.Fatalf("FAIL: %v.NewRequestWithContext() returned an unexpected error: %v", client, err)
+	}
+	start := time.Now()
+	if _, err = client.Do(req); err == nil {
+		t.Fatalf("FAIL: %v.Do() did not return an expected error when its context was done while backing off", client)
+	}
+	if calls != 1 || time.Since(start) > time.Second {
+		t.Fatalf("FAIL: %v.Do() sent %d request(s) in %s, want 1 request and to stop backing off when the context is done",
+			client, calls, time.Since(start))
+	}
+	t.Logf("PASS: Do stops backing off when the context is done")
+}
+
+func TestClient_GetStatus_Retry(t *testing.T) {
+	var calls int
+	pages := servePages(map[string]string{"/" + statusPage: validStatusPage})
+	client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		if calls++; calls == 1 {
+			return newPageResponse(r, 503, ""), nil
+		}
+		return pages(r)
+	})
+	client.SetRetryPolicy(&RetryPolicy{MinBackoff: time.Millisecond})
+	if _, err := client.GetStatus(); err != nil {
+		t.Fatalf("FAIL: %v.GetStatus() returned an unexpected error after a 503 with a retry policy: %v", client, err)
+	}
+	t.Logf("PASS: GetStatus retried after a 503")
+}
+
+func TestClient_GetWiredConnections_Retry(t *testing.T) {
+	var calls int
+	client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		if calls++; calls == 1 {
+			return newPageResponse(r, 503, ""), nil
+		}
+		return newPageResponse(r, 200, validConnectionsJSONResponse), nil
+	})
+	client.SetRetryPolicy(&RetryPolicy{MinBackoff: time.Millisecond})
+	if _, err := client.GetWiredConnections(); err != nil {
+		t.Fatalf("FAIL: %v.GetWiredConnections() returned an unexpected error after a 503 with a retry policy: %v",
+			client, err)
+	}
+	t.Logf("PASS: GetWiredConnections retried after a 503")
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Jitter: 0.5}
+	for _, tt := range []*struct {
+		retry    int
+		expected time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{50, time.Second},
+	} {
+		for i := 0; i < 20; i++ {
+			got := p.backoff(tt.retry)
+			if got > tt.expected || got < tt.expected/2 {
+				t.Fatalf("FAIL: backoff(%d) returned %s, want between %s and %s", tt.retry, got, tt.expected/2, tt.expected)
+			}
+		}
+		t.Logf("PASS: backoff(%d) up to %s", tt.retry, tt.expected)
+	}
+
+	c := newPageTestClient(nil)
+	c.SetRetryPolicy(&RetryPolicy{MinBackoff: 100 * time.Millisecond})
+	if got := c.retry.backoff(1); got != 100*time.Millisecond {
+		t.Fatalf("FAIL: backoff(1) without jitter returned %s, want %s", got, 100*time.Millisecond)
+	}
+	t.Logf("PASS: backoff without jitter")
+
+	c.SetRetryPolicy(&RetryPolicy{Jitter: -1})
+	if c.retry.Jitter != 0.5 {
+		t.Fatalf("FAIL: negative Jitter replaced by %v, want the default 0.5", c.retry.Jitter)
+	}
+	t.Logf("PASS: negative Jitter replaced by the default")
+}
@@ -0,0 +1,73 @@
+package archerc9v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/url"
+	"strconv"
+)
+
+const statisticsPage = "userRpm/SystemStatisticRpm.htm"
+
+// TrafficStatistics holds the traffic the router counted for one node since the
+// statistics were enabled, along with the node's current rates.
+type TrafficStatistics struct {
+	IPAddress        string `json:"ip_addr"`
+	MacAddress       string `json:"mac_addr"`
+	TotalPackets     int64  `json:"total_packets"`
+	TotalBytes       int64  `json:"total_bytes"`
+	PacketsPerSecond int64  `json:"packets_per_second"`
+	BytesPerSecond   int64  `json:"bytes_per_second"`
+}
+
+// GetTrafficStatistics wraps GetTrafficStatisticsContext using context.Background.
+func (c *Client) GetTrafficStatistics() ([]*TrafficStatistics, error) {
+	return c.GetTrafficStatisticsContext(context.Background())
+}
+
+// GetTrafficStatisticsContext returns the router's per node traffic statistics or
+// returns an error otherwise. The router only counts traffic while its statistics
+// are enabled, so they are enabled first if needed, in which case all counters
+// start at zero. The rows of every page of the statistics table are returned.
+func (c *Client) GetTrafficStatisticsContext(ctx context.Context) ([]*TrafficStatistics, error) {
+	q := url.Values{}
+	q.Set("Page", "1")
+	data, err := c.readPage(ctx, statisticsPage, q, "get traffic statistics state")
+	if err != nil {
+		return nil, err
+	}
+
+	para, err := parseJSArray(data, "statisticsPara")
+	if err != nil {
+		return nil, errors.Wrap(err, "got error parsing traffic statistics page")
+	}
+	enabled, err := para.boolAt(0)
+	if err != nil {
+		return nil, errors.Wrap(err, "got invalid traffic statistics state")
+	}
+	if !enabled {
+		q.Set("Enable", "Enable")
+		if _, err = c.getPage(ctx, statisticsPage, q, "enable traffic statistics"); err != nil {
+			return nil, err
+		}
+	}
+
+	rows, err := c.getListRows(ctx, statisticsPage, "get traffic statistics",
+		parseListRows("statList", 6, "traffic statistics page"))
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []*TrafficStatistics
+	for i, row := range rows {
+		s := &TrafficStatistics{IPAddress: row.strAt(0), MacAddress: row.strAt(1)}
+		for j, counter := range []*int64{&s.TotalPackets, &s.TotalBytes, &s.PacketsPerSecond, &s.BytesPerSecond} {
+			if *counter, err = strconv.ParseInt(row.strAt(j+2), 10, 64); err != nil {
+				return nil, errors.Wrap(err, fmt.Sprintf("got invalid counter for traffic statistics row %d", i))
+			}
+		}
+		stats = append(stats, s)
+	}
+	return stats, nil
+}
@@ -0,0 +1,116 @@
+package archerc9v1
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var validStatisticsPage = `<script type="text/javascript">
+var statisticsPara = new Array(
+1,
+10,
+0,0 );
+var statList = new Array(
+"192.168.0.100", "12-34-56-AA-BB-CC", 123456, 98765432109, 12, 34567,
+"192.168.0.101", "1A-1A-1A-AA-AA-AA", 10, 2048, 0, 0,
+0,0 );
+</script>`
+
+var validStatistics = []*TrafficStatistics{
+	{
+		IPAddress:        "192.168.0.100",
+		MacAddress:       "12-34-56-AA-BB-CC",
+		TotalPackets:     123456,
+		TotalBytes:       98765432109,
+		PacketsPerSecond: 12,
+		BytesPerSecond:   34567,
+	},
+	{IPAddress: "192.168.0.101", MacAddress: "1A-1A-1A-AA-AA-AA", TotalPackets: 10, TotalBytes: 2048},
+}
+
+var validStatisticsPage2 = `<script type="text/javascript">
+var statisticsPara = new Array(
+1,
+10,
+0,0 );
+var statList = new Array(
+"192.168.0.102", "3C-3C-3C-CC-CC-CC", 500, 1000000, 1, 100,
+0,0 );
+</script>`
+
+func TestClient_GetTrafficStatistics(t *testing.T) {
+	disabledPage := strings.Replace(validStatisticsPage, "new Array(\n1,", "new Array(\n0,", 1)
+	testCases := []*struct {
+		description  string
+		page         string
+		page2        string
+		expected     []*TrafficStatistics
+		expectEnable bool
+		expectError  bool
+	}{
+		{
+			description: "Invalid counter",
+			page:        strings.Replace(validStatisticsPage, "2048", "lots", 1),
+			expectError: true,
+		},
+		{
+			description: "Enabled statistics",
+			page:        validStatisticsPage,
+			expected:    validStatistics,
+		},
+		{
+			description:  "Disabled statistics",
+			page:         disabledPage,
+			expected:     validStatistics,
+			expectEnable: true,
+		},
+		{
+			description: "Statistics on two pages",
+			page:        validStatisticsPage,
+			page2:       validStatisticsPage2,
+			expected: append(validStatistics[:len(validStatistics):len(validStatistics)], &TrafficStatistics{
+				IPAddress:        "192.168.0.102",
+				MacAddress:       "3C-3C-3C-CC-CC-CC",
+				TotalPackets:     500,
+				TotalBytes:       1000000,
+				PacketsPerSecond: 1,
+				BytesPerSecond:   100,
+			}),
+		},
+	}
+
+	for _, tt := range testCases {
+		enabled := false
+		client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+			if r.URL.Query().Get("Enable") != "" {
+				enabled = true
+				return newPageResponse(r, 200, validStatisticsPage), nil
+			}
+			if tt.page2 != "" {
+				return serveListPages(statisticsPage, tt.page, tt.page2)(r)
+			}
+			return servePages(map[string]string{"/" + statisticsPage: tt.page})(r)
+		})
+		got, err := client.GetTrafficStatistics()
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetTrafficStatistics() did not return an expected error", tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetTrafficStatistics() returned an unexpected error: %v",
+					tt.description, client, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.GetTrafficStatistics() returned %v, want %v", tt.description, client, got, tt.expected)
+			}
+			if enabled != tt.expectEnable {
+				t.Fatalf("FAIL: %s\n\t%v.GetTrafficStatistics() enabled statistics: %t, want %t",
+					tt.description, client, enabled, tt.expectEnable)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
@@ -0,0 +1,181 @@
+package archerc9v1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+)
+
+const statusPage = "userRpm/StatusRpm.htm"
+
+// Status is a snapshot of the router's identity and the state of its LAN, WAN
+// and wireless interfaces, as shown on its status page.
+type Status struct {
+	FirmwareVersion string            `json:"firmware_version"`
+	HardwareVersion string            `json:"hardware_version"`
+	Uptime          time.Duration     `json:"uptime"`
+	LAN             *LANStatus        `json:"lan"`
+	WAN             *WANStatus        `json:"wan"`
+	Wireless        []*WirelessStatus `json:"wireless"`
+}
+
+// LANStatus is the state of the router's LAN interface.
+type LANStatus struct {
+	MacAddress string `json:"mac_addr"`
+	IPAddress  string `json:"ip_addr"`
+	SubnetMask string `json:"subnet_mask"`
+}
+
+// WANStatus is the state of the router's WAN interface. IPAddress is empty while
+// the WAN interface has no address.
+type WANStatus struct {
+	ConnectionType WANConnectionType `json:"connection_type"`
+	MacAddress     string            `json:"mac_addr"`
+	IPAddress      string            `json:"ip_addr"`
+	SubnetMask     string            `json:"subnet_mask"`
+	Gateway        string            `json:"gateway"`
+	DNSServers     []string          `json:"dns_servers"`
+}
+
+// Connected reports whether the WAN interface has an IP address.
+func (w *WANStatus) Connected() bool {
+	return w.IPAddress != ""
+}
+
+// WirelessStatus is the state of one of the router's radios. Channel is the
+// channel the radio currently uses and ChannelWidth is in MHz, where 0 means
+// the router picks the width automatically.
+type WirelessStatus struct {
+	Band         Band   `json:"band"`
+	RadioEnabled bool   `json:"radio_enabled"`
+	SSID         string `json:"ssid"`
+	Channel      int    `json:"channel"`
+	ChannelWidth int    `json:"channel_width"`
+	Mode         string `json:"mode"`
+	MacAddress   string `json:"mac_addr"`
+}
+
+// MarshalJSON encodes the status with its uptime as a string such as "49h3m7s"
+// rather than in nanoseconds.
+func (s *Status) MarshalJSON() ([]byte, error) {
+	type status Status
+	return json.Marshal(&struct {
+		*status
+		Uptime string `json:"uptime"`
+	}{status: (*status)(s), Uptime: s.Uptime.String()})
+}
+
+// GetStatus wraps GetStatusContext using context.Background.
+func (c *Client) GetStatus() (*Status, error) {
+	return c.GetStatusContext(context.Background())
+}
+
+// GetStatusContext returns a snapshot of the router's status or returns an error
+// otherwise.
+func (c *Client) GetStatusContext(ctx context.Context) (*Status, error) {
+	data, err := c.readPage(ctx, statusPage, nil, "get router status")
+	if err != nil {
+		return nil, err
+	}
+
+	para, err := parseJSArray(data, "statusPara")
+	if err != nil {
+		return nil, errors.Wrap(err, "got error parsing status page")
+	}
+	uptime, err := para.intAt(2)
+	if err != nil {
+		return nil, errors.Wrap(err, "got invalid uptime")
+	}
+	s := &Status{
+		FirmwareVersion: para.strAt(0),
+		HardwareVersion: para.strAt(1),
+		Uptime:          time.Duration(uptime) * time.Second,
+	}
+
+	lan, err := parseJSArray(data, "lanPara")
+	if err != nil {
+		return nil, errors.Wrap(err, "got error parsing status page")
+	}
+	s.LAN = &LANStatus{MacAddress: lan.strAt(0), IPAddress: lan.strAt(1), SubnetMask: lan.strAt(2)}
+
+	if s.WAN, err = parseWANStatus(data); err != nil {
+		return nil, err
+	}
+
+	for _, band := range Bands {
+		w, err := parseWirelessStatus(data, band)
+		if err != nil {
+			return nil, err
+		}
+		s.Wireless = append(s.Wireless, w)
+	}
+	return s, nil
+}
+
+// parseWANStatus returns the WAN state from the wanPara array of the status page.
+func parseWANStatus(page []byte) (*WANStatus, error) {
+	wan, err := parseJSArray(page, "wanPara")
+	if err != nil {
+		return nil, errors.Wrap(err, "got error parsing status page")
+	}
+	w := &WANStatus{
+		MacAddress: wan.strAt(1),
+		IPAddress:  emptyIfUnset(wan.strAt(2)),
+		SubnetMask: emptyIfUnset(wan.strAt(3)),
+		Gateway:    emptyIfUnset(wan.strAt(4)),
+	}
+	if w.ConnectionType, err = parseWANConnectionType(wan, 0); err != nil {
+		return nil, err
+	}
+	dns := strings.Split(wan.strAt(5), ",")
+	for i := range dns {
+		dns[i] = strings.TrimSpace(dns[i])
+	}
+	w.DNSServers = dnsServers(dns...)
+	return w, nil
+}
+
+// parseWirelessStatus returns the state of the radio for band from the status
+// page, which holds it in the wlanPara array for 2.4GHz and wlan5GPara for 5GHz.
+func parseWirelessStatus(page []byte, band Band) (*WirelessStatus, error) {
+	name := "wlanPara"
+	if band == Band5GHz {
+		name = "wlan5GPara"
+	}
+	para, err := parseJSArray(page, name)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error parsing status page")
+	}
+
+	w := &WirelessStatus{Band: band, SSID: para.strAt(1), MacAddress: para.strAt(5)}
+	if w.RadioEnabled, err = para.boolAt(0); err != nil {
+		return nil, errors.Wrap(err, "got invalid "+band.String()+" radio state")
+	}
+	if w.Channel, err = para.intAt(2); err != nil {
+		return nil, errors.Wrap(err, "got invalid "+band.String()+" channel")
+	}
+	if w.Mode, err = lookupCode(para, 3, wirelessModes[band], band.String()+" wireless mode"); err != nil {
+		return nil, err
+	}
+	width, err := para.intAt(4)
+	if err != nil {
+		return nil, errors.Wrap(err, "got invalid "+band.String()+" channel width")
+	}
+	var ok bool
+	if w.ChannelWidth, ok = channelWidths[width]; !ok {
+		return nil, fmt.Errorf("got unknown %s channel width code %d", band, width)
+	}
+	return w, nil
+}
+
+// emptyIfUnset returns ip or an empty string if ip is the router's placeholder
+// for an unset address. It is the inverse of orUnsetIP.
+func emptyIfUnset(ip string) string {
+	if ip == unsetIP {
+		return ""
+	}
+	return ip
+}
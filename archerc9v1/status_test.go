@@ -0,0 +1,131 @@
+package archerc9v1
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var validStatusPage = `<script type="text/javascript">
+var statusPara = new Array(
+"3.16.0 0.9.1 v6015.0 Build 160906 Rel.61584n",
+"Archer C9 v1 00000000",
+176587,
+0,0 );
+var lanPara = new Array(
+"50-C7-BF-00-00-01",
+"192.168.0.1",
+"255.255.255.0",
+0,0 );
+var wanPara = new Array(
+0,
+"50-C7-BF-00-00-02",
+"203.0.113.7",
+"255.255.255.0",
+"203.0.113.1",
+"198.51.100.53 , 0.0.0.0",
+0,0 );
+var wlanPara = new Array(
+1,
+"HomeNet",
+6,
+5,
+1,
+"50-C7-BF-00-00-03",
+0,0 );
+var wlan5GPara = new Array(
+0,
+"HomeNet_5G",
+149,
+3,
+4,
+"50-C7-BF-00-00-04",
+0,0 );
+</script>`
+
+var validStatus = &Status{
+	FirmwareVersion: "3.16.0 0.9.1 v6015.0 Build 160906 Rel.61584n",
+	HardwareVersion: "Archer C9 v1 00000000",
+	Uptime:          49*time.Hour + 3*time.Minute + 7*time.Second,
+	LAN:             &LANStatus{MacAddress: "50-C7-BF-00-00-01", IPAddress: "192.168.0.1", SubnetMask: "255.255.255.0"},
+	WAN: &WANStatus{
+		ConnectionType: WANDynamicIP,
+		MacAddress:     "50-C7-BF-00-00-02",
+		IPAddress:      "203.0.113.7",
+		SubnetMask:     "255.255.255.0",
+		Gateway:        "203.0.113.1",
+		DNSServers:     []string{"198.51.100.53"},
+	},
+	Wireless: []*WirelessStatus{
+		{Band: Band24GHz, RadioEnabled: true, SSID: "HomeNet", Channel: 6, Mode: "bgn", MacAddress: "50-C7-BF-00-00-03"},
+		{Band: Band5GHz, SSID: "HomeNet_5G", Channel: 149, ChannelWidth: 80, Mode: "ac", MacAddress: "50-C7-BF-00-00-04"},
+	},
+}
+
+func TestClient_GetStatus(t *testing.T) {
+	disconnectedPage := strings.Replace(strings.Replace(validStatusPage,
+		`"203.0.113.7"`, `"0.0.0.0"`, 1), `"203.0.113.1"`, `"0.0.0.0"`, 1)
+	testCases := []*struct {
+		description string
+		page        string
+		expected    *Status
+		expectError bool
+	}{
+		{
+			description: "Missing wireless parameters",
+			page:        validStatusPage[:strings.Index(validStatusPage, "var wlanPara")],
+			expectError: true,
+		},
+		{
+			description: "Unknown WAN connection type",
+			page:        strings.Replace(validStatusPage, "var wanPara = new Array(\n0,", "var wanPara = new Array(\n9,", 1),
+			expectError: true,
+		},
+		{
+			description: "Connected WAN",
+			page:        validStatusPage,
+			expected:    validStatus,
+		},
+	}
+
+	for _, tt := range testCases {
+		client = newPageTestClient(servePages(map[string]string{"/" + statusPage: tt.page}))
+		got, err := client.GetStatus()
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetStatus() did not return an expected error", tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetStatus() returned an unexpected error: %v", tt.description, client, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.GetStatus() returned %+v, want %+v", tt.description, client, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+
+	client = newPageTestClient(servePages(map[string]string{"/" + statusPage: disconnectedPage}))
+	got, err := client.GetStatus()
+	if err != nil || got.WAN.Connected() || got.WAN.IPAddress != "" || got.WAN.Gateway != "" {
+		t.Fatalf("FAIL: Disconnected WAN\n\t%v.GetStatus() returned (%+v, %v), want a disconnected WAN without addresses",
+			client, got.WAN, err)
+	}
+	t.Logf("PASS: Disconnected WAN")
+}
+
+func TestStatus_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(validStatus)
+	if err != nil {
+		t.Fatalf("FAIL: Valid status\n\tjson.Marshal(%+v) returned an unexpected error: %v", validStatus, err)
+	}
+	for _, want := range []string{`"uptime":"49h3m7s"`, `"band":"5GHz"`, `"firmware_version":"3.16.0`} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("FAIL: Valid status\n\tjson.Marshal(%+v) returned %s, want it to contain %s", validStatus, data, want)
+		}
+	}
+	t.Logf("PASS: Valid status")
+}
@@ -0,0 +1,131 @@
+package archerc9v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const systemLogPage = "userRpm/SystemLogRpm.htm"
+
+// logTimeLayout is the layout of the timestamps in the router's log, which
+// lack the year.
+const logTimeLayout = "Jan 2 15:04:05"
+
+// LogLevel is the severity of a system log entry. Lower values are more severe.
+type LogLevel int
+
+const (
+	LogEmergency LogLevel = iota
+	LogAlert
+	LogCritical
+	LogError
+	LogWarning
+	LogNotice
+	LogInfo
+	LogDebug
+)
+
+// logLevelNames holds the names of the log levels as the router prints them,
+// indexed by LogLevel.
+var logLevelNames = []string{"EMERGENCY", "ALERT", "CRITICAL", "ERROR", "WARNING", "NOTICE", "INFO", "DEBUG"}
+
+// String returns the name of the level.
+func (l LogLevel) String() string {
+	if l >= 0 && int(l) < len(logLevelNames) {
+		return logLevelNames[l]
+	}
+	return "LogLevel(" + strconv.Itoa(int(l)) + ")"
+}
+
+// ParseLogLevel returns the LogLevel named by s, e.g. warning or ERROR.
+func ParseLogLevel(s string) (LogLevel, error) {
+	for i, name := range logLevelNames {
+		if strings.EqualFold(s, name) {
+			return LogLevel(i), nil
+		}
+	}
+	return 0, fmt.Errorf("got log level %q (want one of %s)", s, strings.ToLower(strings.Join(logLevelNames, ", ")))
+}
+
+// MarshalText encodes the level as its name.
+func (l LogLevel) MarshalText() ([]byte, error) {
+	return []byte(l.String()), nil
+}
+
+// LogEntry represents an entry of the router's system log. Type is the
+// subsystem that logged the entry, e.g. DHCP or PPP.
+type LogEntry struct {
+	Time    time.Time `json:"time"`
+	Type    string    `json:"type"`
+	Level   LogLevel  `json:"level"`
+	Message string    `json:"message"`
+}
+
+// GetSystemLog wraps GetSystemLogContext using context.Background.
+func (c *Client) GetSystemLog() ([]*LogEntry, error) {
+	return c.GetSystemLogContext(context.Background())
+}
+
+// GetSystemLogContext returns the entries of the router's system log in the order
+// the router lists them or returns an error otherwise. As the router omits the year
+// from its timestamps, each entry is assumed to lie within the year before now.
+func (c *Client) GetSystemLogContext(ctx context.Context) ([]*LogEntry, error) {
+	data, err := c.readPage(ctx, systemLogPage, nil, "get system log")
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := parseJSArray(data, "logList")
+	if err != nil {
+		return nil, errors.Wrap(err, "got error parsing system log page")
+	}
+
+	now := time.Now()
+	var entries []*LogEntry
+	for i, row := range list.rows(1) {
+		e, err := parseLogEntry(row.strAt(0), now)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("got invalid system log entry %d", i))
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// parseLogEntry parses a line of the router's log in the format
+// "Jan 2 15:04:05 TYPE LEVEL message" into a LogEntry. The year of the
+// timestamp is taken from now, or from the year before if the timestamp would
+// otherwise lie in the future.
+func parseLogEntry(line string, now time.Time) (*LogEntry, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 5 {
+		return nil, fmt.Errorf("got log line %q (want timestamp, type, level and optional message)", line)
+	}
+
+	t, err := time.Parse(logTimeLayout, strings.Join(fields[:3], " "))
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("got invalid timestamp in log line %q", line))
+	}
+	t = time.Date(now.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, now.Location())
+	if t.After(now) {
+		t = t.AddDate(-1, 0, 0)
+	}
+
+	level, err := ParseLogLevel(fields[4])
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("got invalid level in log line %q", line))
+	}
+
+	e := &LogEntry{Time: t, Type: fields[3], Level: level}
+	// Keep the message's own spacing by cutting it after the level.
+	rest := line
+	for _, f := range fields[:5] {
+		rest = rest[strings.Index(rest, f)+len(f):]
+	}
+	e.Message = strings.TrimSpace(rest)
+	return e, nil
+}
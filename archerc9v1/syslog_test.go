@@ -0,0 +1,109 @@
+package archerc9v1
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var validSystemLogPage = `<script type="text/javascript">
+var logList = new Array(
+"Oct 16 07:59:58 DHCP NOTICE DHCPS:Send ACK to 192.168.0.100",
+"Oct  16 08:00:01 PPP  WARNING  LCP  echo request   timed out",
+0,0 );
+</script>`
+
+func TestParseLogEntry(t *testing.T) {
+	now := time.Date(2026, time.January, 2, 12, 0, 0, 0, time.UTC)
+	testCases := []*struct {
+		input       string
+		expected    *LogEntry
+		expectError bool
+	}{
+		{
+			input: "Jan 2 11:59:59 DHCP NOTICE DHCPS:Send ACK to 192.168.0.100",
+			expected: &LogEntry{
+				Time:    time.Date(2026, time.January, 2, 11, 59, 59, 0, time.UTC),
+				Type:    "DHCP",
+				Level:   LogNotice,
+				Message: "DHCPS:Send ACK to 192.168.0.100",
+			},
+		},
+		{
+			input: "Dec 31 23:00:00 SYSTEM INFO Time  synchronized",
+			expected: &LogEntry{
+				Time:    time.Date(2025, time.December, 31, 23, 0, 0, 0, time.UTC),
+				Type:    "SYSTEM",
+				Level:   LogInfo,
+				Message: "Time  synchronized",
+			},
+		},
+		{input: "Jan 2 11:59:59 DHCP", expectError: true},
+		{input: "Foo 2 11:59:59 DHCP NOTICE message", expectError: true},
+		{input: "Jan 2 11:59:59 DHCP LOUD message", expectError: true},
+	}
+
+	for _, tt := range testCases {
+		got, err := parseLogEntry(tt.input, now)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tparseLogEntry(%q) did not return an expected error", tt.input, tt.input)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\tparseLogEntry(%q) returned an unexpected error: %v", tt.input, tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\tparseLogEntry(%q) returned %+v, want %+v", tt.input, tt.input, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.input)
+	}
+}
+
+func TestParseLogLevel(t *testing.T) {
+	for _, tt := range []*struct {
+		input       string
+		expected    LogLevel
+		expectError bool
+	}{
+		{input: "warning", expected: LogWarning},
+		{input: "EMERGENCY", expected: LogEmergency},
+		{input: "Debug", expected: LogDebug},
+		{input: "verbose", expectError: true},
+	} {
+		got, err := ParseLogLevel(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tParseLogLevel(%q) did not return an expected error", tt.input, tt.input)
+			}
+		} else if err != nil || got != tt.expected {
+			t.Fatalf("FAIL: %s\n\tParseLogLevel(%q) returned (%s, %v), want %s", tt.input, tt.input, got, err, tt.expected)
+		}
+		t.Logf("PASS: %s", tt.input)
+	}
+}
+
+func TestClient_GetSystemLog(t *testing.T) {
+	client = newPageTestClient(servePages(map[string]string{"/" + systemLogPage: validSystemLogPage}))
+	got, err := client.GetSystemLog()
+	if err != nil {
+		t.Fatalf("FAIL: Valid response\n\t%v.GetSystemLog() returned an unexpected error: %v", client, err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("FAIL: Valid response\n\t%v.GetSystemLog() returned %d entries, want 2", client, len(got))
+	}
+	e := got[1]
+	if e.Type != "PPP" || e.Level != LogWarning || e.Message != "LCP  echo request   timed out" ||
+		e.Time.Month() != time.October || e.Time.Day() != 16 || e.Time.Hour() != 8 || e.Time.After(time.Now()) {
+		t.Fatalf("FAIL: Valid response\n\t%v.GetSystemLog() returned entry %+v, want the PPP warning of Oct 16 08:00:01",
+			client, e)
+	}
+	t.Logf("PASS: Valid response")
+
+	client = newPageTestClient(servePages(map[string]string{"/" + systemLogPage: `var logList = new Array("garbage", 0,0 );`}))
+	if _, err = client.GetSystemLog(); err == nil {
+		t.Fatalf("FAIL: Invalid entry\n\t%v.GetSystemLog() did not return an expected error", client)
+	}
+	t.Logf("PASS: Invalid entry")
+}
@@ -0,0 +1,295 @@
+package archerc9v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// jsArray holds the elements of a JavaScript array literal embedded in one
+// of the router's userRpm pages.
+type jsArray []string
+
+// getPage sends a GET request for the userRpm page at urlStr with the given
+// query parameters and returns the body of the response. The action describes
+// the request (e.g. "get DHCP settings") and is used in log and error messages.
+func (c *Client) getPage(ctx context.Context, urlStr string, query url.Values, action string) ([]byte, error) {
+	req, err := c.NewRequestWithContext(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error creating request to "+action)
+	}
+	req.URL.RawQuery = query.Encode()
+
+	return c.doPage(req, urlStr, action)
+}
+
+// readPage is like getPage but marks the request as only reading from the router,
+// so that it is retried according to the Client's retry policy.
+func (c *Client) readPage(ctx context.Context, urlStr string, query url.Values, action string) ([]byte, error) {
+	return c.getPage(asRead(ctx), urlStr, query, action)
+}
+
+// uploadPage uploads the content read from r as the file fileName in the form
+// field fieldName of the userRpm form at urlStr, along with the given form
+// fields, and returns the body of the response. The action is used as with
+// getPage.
+func (c *Client) uploadPage(ctx context.Context, urlStr, fieldName, fileName string, r io.Reader,
+	fields map[string]string, action string) ([]byte, error) {
+	req, err := c.NewUploadRequestWithContext(ctx, urlStr, fieldName, fileName, r, fields)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error creating request to "+action)
+	}
+	return c.doPage(req, urlStr, action)
+}
+
+// doPage sends req and returns the body of the response, or an error if the
+// response is not a successful, non-empty response or is the login page.
+func (c *Client) doPage(req *http.Request, urlStr, action string) ([]byte, error) {
+	c.logger.Debug("sending request", "action", action, "method", req.Method, "url", redactURL(req.URL))
+	resp, err := c.Do(req)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "got error doing request to "+action)
+	}
+
+	if err = CheckResponse(resp); err != nil {
+		return nil, errors.Wrap(err, "got error in response to "+action)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error reading body in response to "+action)
+	}
+
+	if len(data) == 0 {
+		return nil, newUnexpectedResponseError(resp, nil, "got empty body in response to "+action)
+	}
+
+	if err = checkBody(data); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("got error in response to %s (want %s)", action, urlStr))
+	}
+
+	return data, nil
+}
+
+// isPageNotFound reports whether err was returned for a userRpm page the router
+// does not have, e.g. because its firmware lacks a feature.
+func isPageNotFound(err error) bool {
+	var e *UnexpectedResponseError
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
+
+// parseJSArray returns the elements of the array declared as
+// "var name = new Array(...)" in page. The router's userRpm pages embed
+// their data this way. String elements are returned without their quotes.
+func parseJSArray(page []byte, name string) (jsArray, error) {
+	decl := regexp.MustCompile(`var\s+` + regexp.QuoteMeta(name) + `\s*=\s*new\s+Array\s*\(`)
+	loc := decl.FindIndex(page)
+	if loc == nil {
+		return nil, fmt.Errorf("got page without a %s array (want var %s = new Array(...))", name, name)
+	}
+
+	var elems jsArray
+	s := string(page[loc[1]:])
+	for i := 0; ; {
+		for i < len(s) && isJSSpace(s[i]) {
+			i++
+		}
+		if i == len(s) {
+			return nil, fmt.Errorf("got unterminated %s array", name)
+		}
+		if s[i] == ')' && len(elems) == 0 {
+			return elems, nil
+		}
+
+		var elem strings.Builder
+		if s[i] == '"' || s[i] == '\'' {
+			quote := s[i]
+			for i++; i < len(s) && s[i] != quote; i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+				}
+				elem.WriteByte(s[i])
+			}
+			if i == len(s) {
+				return nil, fmt.Errorf("got unterminated string in %s array", name)
+			}
+			i++
+		} else {
+			for i < len(s) && s[i] != ',' && s[i] != ')' {
+				elem.WriteByte(s[i])
+				i++
+			}
+		}
+		elems = append(elems, strings.TrimSpace(elem.String()))
+
+		for i < len(s) && isJSSpace(s[i]) {
+			i++
+		}
+		if i == len(s) {
+			return nil, fmt.Errorf("got unterminated %s array", name)
+		}
+		switch s[i] {
+		case ',':
+			i++
+		case ')':
+			return elems, nil
+		default:
+			return nil, fmt.Errorf("got unexpected character %q in %s array", s[i], name)
+		}
+	}
+}
+
+func isJSSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n'
+}
+
+// strAt returns the element at index i or an empty string if the array is
+// too short.
+func (a jsArray) strAt(i int) string {
+	if i < len(a) {
+		return a[i]
+	}
+	return ""
+}
+
+// intAt returns the element at index i as an int or an error if the element
+// is missing or not an integer.
+func (a jsArray) intAt(i int) (int, error) {
+	if i >= len(a) {
+		return 0, fmt.Errorf("got array with %d elements (want at least %d)", len(a), i+1)
+	}
+	n, err := strconv.Atoi(a[i])
+	if err != nil {
+		return 0, errors.Wrap(err, fmt.Sprintf("got invalid integer at index %d", i))
+	}
+	return n, nil
+}
+
+// boolAt returns the element at index i as a bool, treating any non-zero
+// integer as true.
+func (a jsArray) boolAt(i int) (bool, error) {
+	n, err := a.intAt(i)
+	return n != 0, err
+}
+
+// boolParam returns the value the router's forms use for a checked (1) or
+// unchecked (0) option.
+func boolParam(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// rows splits the array into rows of width elements, as used by the router's
+// list pages. The 0,0 terminator the router appends to its arrays and trailing
+// elements that do not fill a complete row are dropped.
+func (a jsArray) rows(width int) []jsArray {
+	if n := len(a); n >= 2 && n%width == 2%width && a[n-2] == "0" && a[n-1] == "0" {
+		a = a[:n-2]
+	}
+	var rows []jsArray
+	for i := 0; i+width <= len(a); i += width {
+		rows = append(rows, a[i:i+width])
+	}
+	return rows
+}
+
+// maxListPages is the maximum number of pages read from one of the router's
+// list pages, in case it keeps answering with new pages past the end of a list.
+const maxListPages = 64
+
+// listPosition is where an entry is shown on the router's list pages: the page,
+// counting from 1, and the index on that page, counting from 0. The router's
+// forms address an entry by both.
+type listPosition struct {
+	page, index int
+}
+
+// set sets the Page parameter and the index parameter named key (e.g. "Del" or
+// "SelIndex") of q to address the entry at p.
+func (p listPosition) set(q url.Values, key string) {
+	q.Set(key, strconv.Itoa(p.index))
+	q.Set("Page", strconv.Itoa(p.page))
+}
+
+// listRow is a row of one of the router's list pages along with its position.
+type listRow struct {
+	jsArray
+	pos listPosition
+}
+
+// getListRows reads the router's list page at urlStr page by page and returns
+// the rows that parse finds on each of them. A page with fewer rows than the
+// first one is the last, as is a page that repeats the previous one, which the
+// router answers for pages past the end of some lists. The action is used as
+// with getPage.
+func (c *Client) getListRows(ctx context.Context, urlStr, action string,
+	parse func(page []byte) ([]jsArray, error)) ([]*listRow, error) {
+	var all []*listRow
+	var fullPage int
+	var prev []jsArray
+	for page := 1; page <= maxListPages; page++ {
+		q := url.Values{}
+		q.Set("Page", strconv.Itoa(page))
+		data, err := c.readPage(ctx, urlStr, q, action)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := parse(data)
+		if err != nil {
+			return nil, err
+		}
+		if page == 1 {
+			fullPage = len(rows)
+		} else if sameKeys(rows, prev) {
+			break
+		}
+		for i, row := range rows {
+			all = append(all, &listRow{jsArray: row, pos: listPosition{page: page, index: i}})
+		}
+		if len(rows) == 0 || len(rows) < fullPage {
+			break
+		}
+		prev = rows
+	}
+	return all, nil
+}
+
+// sameKeys reports whether a and b have the same first element in every row.
+// Other elements, like traffic counters, may change from one request to the
+// next.
+func sameKeys(a, b []jsArray) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].strAt(0) != b[i].strAt(0) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseListRows returns a parse function for getListRows that splits the array
+// name into rows of width elements. Errors name the page being parsed, e.g.
+// "virtual server page".
+func parseListRows(name string, width int, pageName string) func(page []byte) ([]jsArray, error) {
+	return func(page []byte) ([]jsArray, error) {
+		list, err := parseJSArray(page, name)
+		if err != nil {
+			return nil, errors.Wrap(err, "got error parsing "+pageName)
+		}
+		return list.rows(width), nil
+	}
+}
@@ -0,0 +1,212 @@
+package archerc9v1
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newPageResponse returns a response to r with the given status code and body.
+func newPageResponse(r *http.Request, statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Header:     make(http.Header),
+		Request:    r,
+	}
+}
+
+// servePages returns a RoundTripFunc that responds with the page stored in
+// pages under the request's URL path, or with a 404 if there is none.
+func servePages(pages map[string]string) RoundTripFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		page, ok := pages[r.URL.Path]
+		if !ok {
+			return newPageResponse(r, 404, ""), nil
+		}
+		return newPageResponse(r, 200, page), nil
+	}
+}
+
+// serveListPages returns a RoundTripFunc that responds to requests for the list
+// page at urlStr with the page numbered by their Page parameter, or with the last
+// page past the end of the list as the router does, and with a 404 otherwise.
+func serveListPages(urlStr string, pages ...string) RoundTripFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path != "/"+urlStr || len(pages) == 0 {
+			return newPageResponse(r, 404, ""), nil
+		}
+		n, err := strconv.Atoi(r.URL.Query().Get("Page"))
+		if err != nil || n < 1 {
+			n = 1
+		}
+		if n > len(pages) {
+			n = len(pages)
+		}
+		return newPageResponse(r, 200, pages[n-1]), nil
+	}
+}
+
+// newPageTestClient returns a Client whose requests are handled by fn.
+func newPageTestClient(fn RoundTripFunc) *Client {
+	return &Client{
+		userName:         user,
+		password:         password,
+		encodedBasicAuth: validEncodedAuth,
+		baseURL:          validURL,
+		httpClient:       NewTestClient(fn),
+		logger:           testLogger,
+	}
+}
+
+func TestParseJSArray(t *testing.T) {
+	testCases := []*struct {
+		description string
+		page        string
+		expected    jsArray
+		expectError bool
+	}{
+		{
+			description: "Missing array",
+			page:        `<script>var otherPara = new Array(1, 2);</script>`,
+			expectError: true,
+		},
+		{
+			description: "Unterminated array",
+			page:        `<script>var testPara = new Array(1, "a"`,
+			expectError: true,
+		},
+		{
+			description: "Unterminated string",
+			page:        `<script>var testPara = new Array(1, "a);</script>`,
+			expectError: true,
+		},
+		{
+			description: "Empty array",
+			page:        `<script>var testPara = new Array( );</script>`,
+			expected:    nil,
+		},
+		{
+			description: "Mixed elements",
+			page: `<script>
+var testPara = new Array(
+1,
+"192.168.0.100",
+'single',
+"with \"quotes\", and commas",
+"",
+0,0 );
+</script>`,
+			expected: jsArray{"1", "192.168.0.100", "single", `with "quotes", and commas`, "", "0", "0"},
+		},
+	}
+
+	for _, tt := range testCases {
+		got, err := parseJSArray([]byte(tt.page), "testPara")
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tparseJSArray(%q) did not return an expected error",
+					tt.description, tt.page)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\tparseJSArray(%q) returned an unexpected error: %v",
+					tt.description, tt.page, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\tparseJSArray(%q) returned %q, want %q",
+					tt.description, tt.page, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_getPage(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       RoundTripFunc
+		expected    string
+		expectError bool
+	}{
+		{
+			description: "Error getting response",
+			input: func(r *http.Request) (*http.Response, error) {
+				return nil, fmt.Errorf("error")
+			},
+			expectError: true,
+		},
+		{
+			description: "500 status code in response",
+			input: func(r *http.Request) (*http.Response, error) {
+				return newPageResponse(r, 500, ""), nil
+			},
+			expectError: true,
+		},
+		{
+			description: "Empty response",
+			input: func(r *http.Request) (*http.Response, error) {
+				return newPageResponse(r, 200, ""), nil
+			},
+			expectError: true,
+		},
+		{
+			description: "Login page returned",
+			input: func(r *http.Request) (*http.Response, error) {
+				return newPageResponse(r, 200, "<title>TP-LINK Archer C9</title>"), nil
+			},
+			expectError: true,
+		},
+		{
+			description: "Valid page",
+			input: func(r *http.Request) (*http.Response, error) {
+				if r.URL.Query().Get("Page") != "1" {
+					return newPageResponse(r, 200, "missing query"), nil
+				}
+				return newPageResponse(r, 200, "page"), nil
+			},
+			expected: "page",
+		},
+	}
+
+	for _, tt := range testCases {
+		client = newPageTestClient(tt.input)
+		got, err := client.getPage(context.Background(), "userRpm/TestRpm.htm", map[string][]string{"Page": {"1"}}, "get test page")
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.getPage() did not return an expected error",
+					tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.getPage() returned an unexpected error: %v",
+					tt.description, client, err)
+			}
+			if string(got) != tt.expected {
+				t.Fatalf("FAIL: %s\n\t%v.getPage() returned %q, want %q",
+					tt.description, client, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestJSArray_rows(t *testing.T) {
+	a := jsArray{"a", "1", "b", "2", "0"}
+	expected := []jsArray{{"a", "1"}, {"b", "2"}}
+	if got := a.rows(2); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("FAIL: Trailing terminator\n\t%q.rows(2) returned %q, want %q", a, got, expected)
+	}
+	t.Logf("PASS: Trailing terminator")
+
+	a = jsArray{"a", "1", "b", "2", "0", "0"}
+	if got := a.rows(2); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("FAIL: Terminator filling a row\n\t%q.rows(2) returned %q, want %q", a, got, expected)
+	}
+	t.Logf("PASS: Terminator filling a row")
+}
@@ -0,0 +1,342 @@
+package archerc9v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	wanSettingsPage = "userRpm/WanCfgRpm.htm"
+
+	minMTU      = 576
+	maxMTU      = 1500
+	maxPPPoEMTU = 1492
+	// defaultPPPoEMTU is the MTU the router uses for PPPoE by default, while the
+	// other connection types default to maxMTU.
+	defaultPPPoEMTU = 1480
+)
+
+// WANConnectionType is the way the router's WAN interface gets connected to
+// the ISP. Its values are the router's codes for the connection types.
+type WANConnectionType int
+
+const (
+	// WANDynamicIP gets the WAN address from the ISP's DHCP server.
+	WANDynamicIP WANConnectionType = iota
+	// WANStaticIP uses a fixed WAN address assigned by the ISP.
+	WANStaticIP
+	// WANPPPoE logs in to the ISP with PPPoE.
+	WANPPPoE
+)
+
+// String returns the name of the connection type.
+func (t WANConnectionType) String() string {
+	switch t {
+	case WANDynamicIP:
+		return "dynamic"
+	case WANStaticIP:
+		return "static"
+	case WANPPPoE:
+		return "pppoe"
+	}
+	return "WANConnectionType(" + strconv.Itoa(int(t)) + ")"
+}
+
+// ParseWANConnectionType returns the WANConnectionType named by s, which is one
+// of dynamic, static or pppoe.
+func ParseWANConnectionType(s string) (WANConnectionType, error) {
+	for _, t := range []WANConnectionType{WANDynamicIP, WANStaticIP, WANPPPoE} {
+		if strings.EqualFold(s, t.String()) {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("got WAN connection type %q (want dynamic, static or pppoe)", s)
+}
+
+// MarshalText encodes the connection type as its name.
+func (t WANConnectionType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText decodes a connection type name accepted by ParseWANConnectionType.
+func (t *WANConnectionType) UnmarshalText(text []byte) error {
+	parsed, err := ParseWANConnectionType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = parsed
+	return nil
+}
+
+// parseWANConnectionType returns the connection type for the code at index i
+// of para.
+func parseWANConnectionType(para jsArray, i int) (WANConnectionType, error) {
+	code, err := para.intAt(i)
+	if err != nil {
+		return 0, errors.Wrap(err, "got invalid WAN connection type")
+	}
+	t := WANConnectionType(code)
+	if t != WANDynamicIP && t != WANStaticIP && t != WANPPPoE {
+		return 0, fmt.Errorf("got unknown WAN connection type code %d", code)
+	}
+	return t, nil
+}
+
+// DynamicIPConfig holds the settings of a Dynamic IP WAN connection. If
+// DNSServers is empty, the DNS servers handed out by the ISP are used.
+type DynamicIPConfig struct {
+	HostName   string   `json:"host_name"`
+	DNSServers []string `json:"dns_servers"`
+}
+
+// StaticIPConfig holds the settings of a Static IP WAN connection. At least one
+// DNS server is required.
+type StaticIPConfig struct {
+	IPAddress  string   `json:"ip_addr"`
+	SubnetMask string   `json:"subnet_mask"`
+	Gateway    string   `json:"gateway"`
+	DNSServers []string `json:"dns_servers"`
+}
+
+// PPPoEConfig holds the settings of a PPPoE WAN connection. ServiceName is only
+// needed if the ISP requires it.
+type PPPoEConfig struct {
+	UserName    string `json:"user_name"`
+	Password    string `json:"password"`
+	ServiceName string `json:"service_name"`
+}
+
+// WANSettings represents the settings of the router's WAN interface. Only the
+// config for Type is used and must be non-nil. An MTU of 0 means the default
+// for the connection type (1500, or 1480 for PPPoE). If MACClone is not empty,
+// the WAN interface uses that MAC address instead of its own, as some ISPs only
+// talk to a registered MAC address.
+type WANSettings struct {
+	Type     WANConnectionType `json:"type"`
+	Dynamic  *DynamicIPConfig  `json:"dynamic,omitempty"`
+	Static   *StaticIPConfig   `json:"static,omitempty"`
+	PPPoE    *PPPoEConfig      `json:"pppoe,omitempty"`
+	MTU      int               `json:"mtu"`
+	MACClone string            `json:"mac_clone"`
+}
+
+// GetWANSettings wraps GetWANSettingsContext using context.Background.
+func (c *Client) GetWANSettings() (*WANSettings, error) {
+	return c.GetWANSettingsContext(context.Background())
+}
+
+// GetWANSettingsContext returns the settings of the router's WAN interface, with
+// the config of the current connection type filled in, or returns an error
+// otherwise.
+func (c *Client) GetWANSettingsContext(ctx context.Context) (*WANSettings, error) {
+	data, err := c.readPage(ctx, wanSettingsPage, nil, "get WAN settings")
+	if err != nil {
+		return nil, err
+	}
+
+	para, err := parseJSArray(data, "wanPara")
+	if err != nil {
+		return nil, errors.Wrap(err, "got error parsing WAN settings page")
+	}
+	s := &WANSettings{}
+	if s.Type, err = parseWANConnectionType(para, 0); err != nil {
+		return nil, err
+	}
+	if s.MTU, err = para.intAt(1); err != nil {
+		return nil, errors.Wrap(err, "got invalid MTU")
+	}
+	clone, err := para.boolAt(2)
+	if err != nil {
+		return nil, errors.Wrap(err, "got invalid MAC clone state")
+	}
+	if clone {
+		s.MACClone = para.strAt(3)
+	}
+
+	switch s.Type {
+	case WANDynamicIP:
+		dyn, err := parseJSArray(data, "dynamicPara")
+		if err != nil {
+			return nil, errors.Wrap(err, "got error parsing WAN settings page")
+		}
+		s.Dynamic = &DynamicIPConfig{HostName: dyn.strAt(0), DNSServers: dnsServers(dyn.strAt(1), dyn.strAt(2))}
+	case WANStaticIP:
+		static, err := parseJSArray(data, "staticPara")
+		if err != nil {
+			return nil, errors.Wrap(err, "got error parsing WAN settings page")
+		}
+		s.Static = &StaticIPConfig{
+			IPAddress:  static.strAt(0),
+			SubnetMask: static.strAt(1),
+			Gateway:    emptyIfUnset(static.strAt(2)),
+			DNSServers: dnsServers(static.strAt(3), static.strAt(4)),
+		}
+	case WANPPPoE:
+		pppoe, err := parseJSArray(data, "pppoePara")
+		if err != nil {
+			return nil, errors.Wrap(err, "got error parsing WAN settings page")
+		}
+		s.PPPoE = &PPPoEConfig{UserName: pppoe.strAt(0), Password: pppoe.strAt(1), ServiceName: pppoe.strAt(2)}
+	}
+	return s, nil
+}
+
+// SetWANSettings wraps SetWANSettingsContext using context.Background.
+func (c *Client) SetWANSettings(s *WANSettings) error {
+	return c.SetWANSettingsContext(context.Background(), s)
+}
+
+// SetWANSettingsContext changes the settings of the router's WAN interface to s. An
+// error is returned if the settings are invalid or cannot be applied. Beware that
+// the router drops its WAN connection while it applies the settings.
+func (c *Client) SetWANSettingsContext(ctx context.Context, s *WANSettings) error {
+	if s == nil {
+		return errors.New("got nil WAN settings (want non-nil settings)")
+	}
+	if err := s.Validate(); err != nil {
+		return errors.Wrap(err, "got invalid WAN settings")
+	}
+
+	q := url.Values{}
+	q.Set("wantype", strconv.Itoa(int(s.Type)))
+	mtu := s.MTU
+	if mtu == 0 {
+		mtu = maxMTU
+		if s.Type == WANPPPoE {
+			mtu = defaultPPPoEMTU
+		}
+	}
+	q.Set("mtu", strconv.Itoa(mtu))
+	q.Set("macClone", boolParam(s.MACClone != ""))
+	if s.MACClone != "" {
+		mac, _ := NormalizeMAC(s.MACClone)
+		q.Set("cloneMac", mac)
+	}
+
+	switch s.Type {
+	case WANDynamicIP:
+		q.Set("hostName", s.Dynamic.HostName)
+		setDNSServers(q, s.Dynamic.DNSServers)
+	case WANStaticIP:
+		q.Set("ip", s.Static.IPAddress)
+		q.Set("mask", s.Static.SubnetMask)
+		q.Set("gateway", orUnsetIP(s.Static.Gateway))
+		setDNSServers(q, s.Static.DNSServers)
+	case WANPPPoE:
+		q.Set("acc", s.PPPoE.UserName)
+		q.Set("psw", s.PPPoE.Password)
+		q.Set("svcName", s.PPPoE.ServiceName)
+	}
+	q.Set("Save", "Save")
+
+	_, err := c.getPage(ctx, wanSettingsPage, q, "set WAN settings")
+	return err
+}
+
+// Validate checks that s describes WAN settings the router accepts.
+func (s *WANSettings) Validate() error {
+	maxTypeMTU := maxMTU
+	switch s.Type {
+	case WANDynamicIP:
+		if s.Dynamic == nil {
+			return errors.New("got dynamic connection type without a dynamic IP config (want non-nil Dynamic)")
+		}
+		if err := validateDNSServers(s.Dynamic.DNSServers, false); err != nil {
+			return err
+		}
+	case WANStaticIP:
+		if s.Static == nil {
+			return errors.New("got static connection type without a static IP config (want non-nil Static)")
+		}
+		if err := s.Static.validate(); err != nil {
+			return err
+		}
+	case WANPPPoE:
+		if s.PPPoE == nil {
+			return errors.New("got pppoe connection type without a PPPoE config (want non-nil PPPoE)")
+		}
+		if s.PPPoE.UserName == "" || s.PPPoE.Password == "" {
+			return errors.New("got PPPoE config without a user name or password (want both)")
+		}
+		maxTypeMTU = maxPPPoEMTU
+	default:
+		return fmt.Errorf("got WAN connection type %s (want dynamic, static or pppoe)", s.Type)
+	}
+
+	if s.MTU != 0 && (s.MTU < minMTU || s.MTU > maxTypeMTU) {
+		return fmt.Errorf("got MTU %d for %s (want 0 for the default or %d to %d)", s.MTU, s.Type, minMTU, maxTypeMTU)
+	}
+	if s.MACClone != "" {
+		if _, err := NormalizeMAC(s.MACClone); err != nil {
+			return errors.Wrap(err, "got invalid MAC clone address")
+		}
+	}
+	return nil
+}
+
+func (s *StaticIPConfig) validate() error {
+	ip, err := parseIPv4(s.IPAddress)
+	if err != nil {
+		return errors.Wrap(err, "got invalid static WAN IP address")
+	}
+	mask, err := parseIPv4(s.SubnetMask)
+	if err != nil {
+		return errors.Wrap(err, "got invalid static WAN subnet mask")
+	}
+	if ones, bits := net.IPMask(mask).Size(); bits == 0 || ones == 0 {
+		return fmt.Errorf("got static WAN subnet mask %s (want a contiguous non-zero mask)", s.SubnetMask)
+	}
+	if s.Gateway != "" {
+		gw, err := parseIPv4(s.Gateway)
+		if err != nil {
+			return errors.Wrap(err, "got invalid static WAN gateway")
+		}
+		subnet := &net.IPNet{IP: ip.Mask(net.IPMask(mask)), Mask: net.IPMask(mask)}
+		if !subnet.Contains(gw) || gw.Equal(ip) {
+			return fmt.Errorf("got static WAN gateway %s (want another address in %s)", s.Gateway, subnet)
+		}
+	}
+	return validateDNSServers(s.DNSServers, true)
+}
+
+// validateDNSServers checks that dns holds at most two IPv4 addresses and, if
+// required, at least one.
+func validateDNSServers(dns []string, required bool) error {
+	if required && len(dns) == 0 {
+		return errors.New("got no DNS servers (want 1 or 2)")
+	}
+	if len(dns) > 2 {
+		return fmt.Errorf("got %d DNS servers (want at most 2)", len(dns))
+	}
+	for _, d := range dns {
+		if _, err := parseIPv4(d); err != nil {
+			return errors.Wrap(err, "got invalid DNS server")
+		}
+	}
+	return nil
+}
+
+// dnsServers returns the set DNS servers among the router's primary and
+// secondary DNS server fields.
+func dnsServers(fields ...string) []string {
+	var dns []string
+	for _, f := range fields {
+		if f = emptyIfUnset(f); f != "" {
+			dns = append(dns, f)
+		}
+	}
+	return dns
+}
+
+func setDNSServers(q url.Values, dns []string) {
+	q.Set("dns1", unsetIP)
+	q.Set("dns2", unsetIP)
+	for i, d := range dns {
+		q.Set("dns"+strconv.Itoa(i+1), d)
+	}
+}
@@ -0,0 +1,257 @@
+package archerc9v1
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+// newWANSettingsPage returns a WAN settings page for the given connection type code.
+func newWANSettingsPage(typeCode string) string {
+	return `<script type="text/javascript">
+var wanPara = new Array(
+` + typeCode + `,
+1480,
+1,
+"12-34-56-AA-BB-CC",
+0,0 );
+var dynamicPara = new Array("", "0.0.0.0", "0.0.0.0", 0,0 );
+var staticPara = new Array("203.0.113.7", "255.255.255.0", "203.0.113.1", "198.51.100.53", "198.51.100.54", 0,0 );
+var pppoePara = new Array("user@isp", "secret", "", 0,0 );
+</script>`
+}
+
+func TestClient_GetWANSettings(t *testing.T) {
+	testCases := []*struct {
+		description string
+		page        string
+		expected    *WANSettings
+		expectError bool
+	}{
+		{
+			description: "Unknown connection type",
+			page:        newWANSettingsPage("7"),
+			expectError: true,
+		},
+		{
+			description: "Dynamic IP",
+			page:        newWANSettingsPage("0"),
+			expected: &WANSettings{
+				Type:     WANDynamicIP,
+				Dynamic:  &DynamicIPConfig{},
+				MTU:      1480,
+				MACClone: "12-34-56-AA-BB-CC",
+			},
+		},
+		{
+			description: "Static IP",
+			page:        newWANSettingsPage("1"),
+			expected: &WANSettings{
+				Type: WANStaticIP,
+				Static: &StaticIPConfig{
+					IPAddress:  "203.0.113.7",
+					SubnetMask: "255.255.255.0",
+					Gateway:    "203.0.113.1",
+					DNSServers: []string{"198.51.100.53", "198.51.100.54"},
+				},
+				MTU:      1480,
+				MACClone: "12-34-56-AA-BB-CC",
+			},
+		},
+		{
+			description: "PPPoE",
+			page:        newWANSettingsPage("2"),
+			expected: &WANSettings{
+				Type:     WANPPPoE,
+				PPPoE:    &PPPoEConfig{UserName: "user@isp", Password: "secret"},
+				MTU:      1480,
+				MACClone: "12-34-56-AA-BB-CC",
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		client = newPageTestClient(servePages(map[string]string{"/" + wanSettingsPage: tt.page}))
+		got, err := client.GetWANSettings()
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetWANSettings() did not return an expected error", tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetWANSettings() returned an unexpected error: %v", tt.description, client, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.GetWANSettings() returned %+v, want %+v", tt.description, client, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_SetWANSettings(t *testing.T) {
+	var saved url.Values
+	client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		saved = r.URL.Query()
+		return servePages(map[string]string{"/" + wanSettingsPage: newWANSettingsPage("0")})(r)
+	})
+
+	testCases := []*struct {
+		description string
+		input       *WANSettings
+		expected    url.Values
+	}{
+		{
+			description: "Dynamic IP with default MTU",
+			input:       &WANSettings{Type: WANDynamicIP, Dynamic: &DynamicIPConfig{HostName: "gw"}},
+			expected: url.Values{
+				"wantype":  {"0"},
+				"mtu":      {"1500"},
+				"macClone": {"0"},
+				"hostName": {"gw"},
+				"dns1":     {"0.0.0.0"},
+				"dns2":     {"0.0.0.0"},
+				"Save":     {"Save"},
+			},
+		},
+		{
+			description: "Static IP",
+			input: &WANSettings{
+				Type: WANStaticIP,
+				Static: &StaticIPConfig{
+					IPAddress:  "203.0.113.7",
+					SubnetMask: "255.255.255.0",
+					Gateway:    "203.0.113.1",
+					DNSServers: []string{"198.51.100.53"},
+				},
+				MTU: 1400,
+			},
+			expected: url.Values{
+				"wantype":  {"1"},
+				"mtu":      {"1400"},
+				"macClone": {"0"},
+				"ip":       {"203.0.113.7"},
+				"mask":     {"255.255.255.0"},
+				"gateway":  {"203.0.113.1"},
+				"dns1":     {"198.51.100.53"},
+				"dns2":     {"0.0.0.0"},
+				"Save":     {"Save"},
+			},
+		},
+		{
+			description: "PPPoE with MAC clone",
+			input: &WANSettings{
+				Type:     WANPPPoE,
+				PPPoE:    &PPPoEConfig{UserName: "user@isp", Password: "secret", ServiceName: "fiber"},
+				MACClone: "12:34:56:aa:bb:cc",
+			},
+			expected: url.Values{
+				"wantype":  {"2"},
+				"mtu":      {"1480"},
+				"macClone": {"1"},
+				"cloneMac": {"12-34-56-AA-BB-CC"},
+				"acc":      {"user@isp"},
+				"psw":      {"secret"},
+				"svcName":  {"fiber"},
+				"Save":     {"Save"},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		if err := client.SetWANSettings(tt.input); err != nil {
+			t.Fatalf("FAIL: %s\n\t%v.SetWANSettings(%+v) returned an unexpected error: %v",
+				tt.description, client, tt.input, err)
+		}
+		if !reflect.DeepEqual(saved, tt.expected) {
+			t.Fatalf("FAIL: %s\n\t%v.SetWANSettings(%+v) submitted %v, want %v",
+				tt.description, client, tt.input, saved, tt.expected)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestWANSettings_Validate(t *testing.T) {
+	validStatic := func() *StaticIPConfig {
+		return &StaticIPConfig{
+			IPAddress:  "203.0.113.7",
+			SubnetMask: "255.255.255.0",
+			Gateway:    "203.0.113.1",
+			DNSServers: []string{"198.51.100.53"},
+		}
+	}
+	testCases := []*struct {
+		description string
+		input       *WANSettings
+		expectError bool
+	}{
+		{
+			description: "Valid static IP",
+			input:       &WANSettings{Type: WANStaticIP, Static: validStatic()},
+		},
+		{
+			description: "Missing config for type",
+			input:       &WANSettings{Type: WANPPPoE, Static: validStatic()},
+			expectError: true,
+		},
+		{
+			description: "Unknown type",
+			input:       &WANSettings{Type: WANConnectionType(5)},
+			expectError: true,
+		},
+		{
+			description: "Gateway outside of subnet",
+			input: func() *WANSettings {
+				s := validStatic()
+				s.Gateway = "198.51.100.1"
+				return &WANSettings{Type: WANStaticIP, Static: s}
+			}(),
+			expectError: true,
+		},
+		{
+			description: "Static IP without DNS servers",
+			input: func() *WANSettings {
+				s := validStatic()
+				s.DNSServers = nil
+				return &WANSettings{Type: WANStaticIP, Static: s}
+			}(),
+			expectError: true,
+		},
+		{
+			description: "Too many DNS servers",
+			input: &WANSettings{
+				Type:    WANDynamicIP,
+				Dynamic: &DynamicIPConfig{DNSServers: []string{"1.1.1.1", "8.8.8.8", "9.9.9.9"}},
+			},
+			expectError: true,
+		},
+		{
+			description: "PPPoE without password",
+			input:       &WANSettings{Type: WANPPPoE, PPPoE: &PPPoEConfig{UserName: "user@isp"}},
+			expectError: true,
+		},
+		{
+			description: "PPPoE MTU too large",
+			input:       &WANSettings{Type: WANPPPoE, PPPoE: &PPPoEConfig{UserName: "user@isp", Password: "secret"}, MTU: 1500},
+			expectError: true,
+		},
+		{
+			description: "Invalid MAC clone",
+			input:       &WANSettings{Type: WANDynamicIP, Dynamic: &DynamicIPConfig{}, MACClone: "12-34"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		err := tt.input.Validate()
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%+v.Validate() did not return an expected error", tt.description, tt.input)
+			}
+		} else if err != nil {
+			t.Fatalf("FAIL: %s\n\t%+v.Validate() returned an unexpected error: %v", tt.description, tt.input, err)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
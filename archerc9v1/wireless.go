@@ -0,0 +1,265 @@
+package archerc9v1
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const wirelessSettingsPage = "userRpm/WlanNetworkRpm.htm"
+
+// Band is one of the radio bands of the dual band Archer C9.
+type Band int
+
+const (
+	Band24GHz Band = iota
+	Band5GHz
+)
+
+// Bands holds all radio bands of the router.
+var Bands = []Band{Band24GHz, Band5GHz}
+
+// String returns the name of the band.
+func (b Band) String() string {
+	switch b {
+	case Band24GHz:
+		return "2.4GHz"
+	case Band5GHz:
+		return "5GHz"
+	}
+	return "Band(" + strconv.Itoa(int(b)) + ")"
+}
+
+// ParseBand returns the Band named by s, e.g. 2.4g, 2.4GHz, 5g or 5GHz.
+func ParseBand(s string) (Band, error) {
+	switch strings.TrimSuffix(strings.TrimSuffix(strings.ToLower(s), "hz"), "g") {
+	case "2.4", "2":
+		return Band24GHz, nil
+	case "5":
+		return Band5GHz, nil
+	}
+	return 0, fmt.Errorf("got band %q (want 2.4g or 5g)", s)
+}
+
+// MarshalText encodes the band as its name, so that it appears as e.g. "5GHz"
+// in JSON.
+func (b Band) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText decodes a band name accepted by ParseBand.
+func (b *Band) UnmarshalText(text []byte) error {
+	band, err := ParseBand(string(text))
+	if err != nil {
+		return err
+	}
+	*b = band
+	return nil
+}
+
+// page returns the userRpm page for band given the page of the 2.4GHz band.
+// The router serves the 5GHz version of a page with a _5g suffix.
+func (b Band) page(page24GHz string) string {
+	if b == Band5GHz {
+		return strings.TrimSuffix(page24GHz, ".htm") + "_5g.htm"
+	}
+	return page24GHz
+}
+
+// wirelessModes maps the router's codes for the wireless modes of each band
+// to the modes' names.
+var wirelessModes = map[Band]map[int]string{
+	Band24GHz: {1: "b", 2: "g", 3: "n", 4: "bg", 5: "bgn"},
+	Band5GHz:  {1: "a", 2: "n", 3: "ac", 4: "an", 5: "anac"},
+}
+
+// channelWidths maps the router's codes for channel widths to the widths in
+// MHz, where 0 means the router picks the width automatically.
+var channelWidths = map[int]int{1: 0, 2: 20, 3: 40, 4: 80}
+
+// transmitPowers maps the router's codes for transmit power levels to the
+// levels' names.
+var transmitPowers = map[int]string{1: "high", 2: "middle", 3: "low"}
+
+// channels holds the channels the router can use on each band.
+var channels = map[Band][]int{
+	Band24GHz: {1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13},
+	Band5GHz:  {36, 40, 44, 48, 52, 56, 60, 64, 100, 104, 108, 112, 116, 120, 124, 128, 132, 136, 140, 149, 153, 157, 161, 165},
+}
+
+// WirelessSettings represents the settings of one of the router's radios. A Channel
+// or ChannelWidth of 0 means the router picks it automatically. ChannelWidth is in
+// MHz. Mode is the combination of 802.11 standards the radio supports, e.g. bgn for
+// 2.4GHz or anac for 5GHz, and TransmitPower is one of high, middle or low.
+type WirelessSettings struct {
+	Band          Band   `json:"band"`
+	RadioEnabled  bool   `json:"radio_enabled"`
+	SSID          string `json:"ssid"`
+	BroadcastSSID bool   `json:"broadcast_ssid"`
+	Channel       int    `json:"channel"`
+	ChannelWidth  int    `json:"channel_width"`
+	Mode          string `json:"mode"`
+	TransmitPower string `json:"transmit_power"`
+}
+
+// GetWirelessSettings wraps GetWirelessSettingsContext using context.Background.
+func (c *Client) GetWirelessSettings(band Band) (*WirelessSettings, error) {
+	return c.GetWirelessSettingsContext(context.Background(), band)
+}
+
+// GetWirelessSettingsContext returns the settings of the router's radio for the
+// given band or returns an error otherwise.
+func (c *Client) GetWirelessSettingsContext(ctx context.Context, band Band) (*WirelessSettings, error) {
+	action := "get " + band.String() + " wireless settings"
+	data, err := c.readPage(ctx, band.page(wirelessSettingsPage), nil, action)
+	if err != nil {
+		return nil, err
+	}
+
+	para, err := parseJSArray(data, "wlanPara")
+	if err != nil {
+		return nil, errors.Wrap(err, "got error parsing "+band.String()+" wireless settings page")
+	}
+
+	s := &WirelessSettings{Band: band, SSID: para.strAt(0)}
+	if s.RadioEnabled, err = para.boolAt(1); err != nil {
+		return nil, errors.Wrap(err, "got invalid radio state")
+	}
+	if s.BroadcastSSID, err = para.boolAt(2); err != nil {
+		return nil, errors.Wrap(err, "got invalid SSID broadcast state")
+	}
+	if s.Channel, err = para.intAt(3); err != nil {
+		return nil, errors.Wrap(err, "got invalid channel")
+	}
+	if s.Mode, err = lookupCode(para, 4, wirelessModes[band], "wireless mode"); err != nil {
+		return nil, err
+	}
+	width, err := para.intAt(5)
+	if err != nil {
+		return nil, errors.Wrap(err, "got invalid channel width")
+	}
+	var ok bool
+	if s.ChannelWidth, ok = channelWidths[width]; !ok {
+		return nil, fmt.Errorf("got unknown channel width code %d", width)
+	}
+	if s.TransmitPower, err = lookupCode(para, 6, transmitPowers, "transmit power"); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SetWirelessSettings wraps SetWirelessSettingsContext using context.Background.
+func (c *Client) SetWirelessSettings(s *WirelessSettings) error {
+	return c.SetWirelessSettingsContext(context.Background(), s)
+}
+
+// SetWirelessSettingsContext changes the settings of the router's radio for s.Band
+// to s. An error is returned if the settings are invalid for the band or cannot be
+// applied.
+func (c *Client) SetWirelessSettingsContext(ctx context.Context, s *WirelessSettings) error {
+	if s == nil {
+		return errors.New("got nil wireless settings (want non-nil settings)")
+	}
+	if err := s.Validate(); err != nil {
+		return errors.Wrap(err, "got invalid wireless settings")
+	}
+
+	q := url.Values{}
+	q.Set("ssid1", s.SSID)
+	q.Set("ap", boolParam(s.RadioEnabled))
+	q.Set("broadcast", boolParam(s.BroadcastSSID))
+	q.Set("channel", strconv.Itoa(s.Channel))
+	q.Set("mode", strconv.Itoa(codeOf(wirelessModes[s.Band], s.Mode)))
+	q.Set("chanWidth", strconv.Itoa(codes(channelWidths)[s.ChannelWidth]))
+	q.Set("power", strconv.Itoa(codeOf(transmitPowers, s.TransmitPower)))
+	q.Set("Save", "Save")
+
+	_, err := c.getPage(ctx, s.Band.page(wirelessSettingsPage), q, "set "+s.Band.String()+" wireless settings")
+	return err
+}
+
+// Validate checks that s describes settings the router accepts for s.Band.
+func (s *WirelessSettings) Validate() error {
+	if s.Band != Band24GHz && s.Band != Band5GHz {
+		return fmt.Errorf("got band %s (want 2.4GHz or 5GHz)", s.Band)
+	}
+	if len(s.SSID) == 0 || len(s.SSID) > 32 {
+		return fmt.Errorf("got SSID %q (want 1 to 32 characters)", s.SSID)
+	}
+	if s.Channel != 0 && !containsInt(channels[s.Band], s.Channel) {
+		return fmt.Errorf("got channel %d for %s (want 0 for auto or one of %v)",
+			s.Channel, s.Band, channels[s.Band])
+	}
+	if _, ok := codes(channelWidths)[s.ChannelWidth]; !ok || (s.Band == Band24GHz && s.ChannelWidth == 80) {
+		return fmt.Errorf("got channel width %d MHz for %s (want 0 for auto, 20, 40 or, on 5GHz only, 80)",
+			s.ChannelWidth, s.Band)
+	}
+	if codeOf(wirelessModes[s.Band], s.Mode) == 0 {
+		return fmt.Errorf("got mode %q for %s (want one of %s)",
+			s.Mode, s.Band, strings.Join(names(wirelessModes[s.Band]), ", "))
+	}
+	if codeOf(transmitPowers, s.TransmitPower) == 0 {
+		return fmt.Errorf("got transmit power %q (want one of %s)",
+			s.TransmitPower, strings.Join(names(transmitPowers), ", "))
+	}
+	return nil
+}
+
+// lookupCode returns the name for the code at index i of para.
+func lookupCode(para jsArray, i int, table map[int]string, what string) (string, error) {
+	code, err := para.intAt(i)
+	if err != nil {
+		return "", errors.Wrap(err, "got invalid "+what)
+	}
+	name, ok := table[code]
+	if !ok {
+		return "", fmt.Errorf("got unknown %s code %d", what, code)
+	}
+	return name, nil
+}
+
+// codeOf returns the code for name, or 0 if there is none.
+func codeOf(table map[int]string, name string) int {
+	for code, n := range table {
+		if strings.EqualFold(n, name) {
+			return code
+		}
+	}
+	return 0
+}
+
+// codes inverts a code table.
+func codes(values map[int]int) map[int]int {
+	inverted := make(map[int]int, len(values))
+	for code, v := range values {
+		inverted[v] = code
+	}
+	return inverted
+}
+
+// names returns the names of a code table ordered by code.
+func names(table map[int]string) []string {
+	var keys []int
+	for code := range table {
+		keys = append(keys, code)
+	}
+	sort.Ints(keys)
+	var sorted []string
+	for _, k := range keys {
+		sorted = append(sorted, table[k])
+	}
+	return sorted
+}
+
+func containsInt(values []int, v int) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package archerc9v1
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"github.com/pkg/errors"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	wirelessSecurityPage = "userRpm/WlanSecurityRpm.htm"
+
+	// pskSecurityType is the router's code for WPA/WPA2-Personal security.
+	pskSecurityType = 3
+
+	minPassphraseLength = 8
+	maxPassphraseLength = 63
+
+	minGroupKeyUpdatePeriod = 30 * time.Second
+)
+
+// securityVersions maps the router's codes for the WPA versions to their names.
+var securityVersions = map[int]string{1: "auto", 2: "wpa", 3: "wpa2"}
+
+// securityCiphers maps the router's codes for the encryption ciphers to their names.
+var securityCiphers = map[int]string{1: "auto", 2: "tkip", 3: "aes"}
+
+// Passphrase character sets accepted by GeneratePassphrase.
+var passphraseCharsets = map[string]string{
+	"digits":    "0123456789",
+	"hex":       "0123456789abcdef",
+	"alpha":     "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"alnum":     "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789",
+	"printable": "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!#$%&()*+,-./:;<=>?@[]^_{|}~",
+}
+
+// WirelessSecurity represents the WPA/WPA2-Personal (PSK) security settings of one
+// of the router's radios. If Enabled is false, the network is open and the other
+// settings are ignored. Version is one of auto, wpa or wpa2 and Cipher is one of
+// auto, tkip or aes. A GroupKeyUpdatePeriod of 0 means the group key is never
+// updated.
+type WirelessSecurity struct {
+	Band                 Band          `json:"band"`
+	Enabled              bool          `json:"enabled"`
+	Version              string        `json:"version"`
+	Cipher               string        `json:"cipher"`
+	Passphrase           string        `json:"passphrase"`
+	GroupKeyUpdatePeriod time.Duration `json:"group_key_update_period"`
+}
+
+// GetWirelessSecurity wraps GetWirelessSecurityContext using context.Background.
+func (c *Client) GetWirelessSecurity(band Band) (*WirelessSecurity, error) {
+	return c.GetWirelessSecurityContext(context.Background(), band)
+}
+
+// GetWirelessSecurityContext returns the security settings of the router's radio
+// for the given band or returns an error otherwise.
+func (c *Client) GetWirelessSecurityContext(ctx context.Context, band Band) (*WirelessSecurity, error) {
+	data, err := c.readPage(ctx, band.page(wirelessSecurityPage), nil, "get "+band.String()+" wireless security")
+	if err != nil {
+		return nil, err
+	}
+
+	para, err := parseJSArray(data, "wlanSecPara")
+	if err != nil {
+		return nil, errors.Wrap(err, "got error parsing "+band.String()+" wireless security page")
+	}
+
+	secType, err := para.intAt(0)
+	if err != nil {
+		return nil, errors.Wrap(err, "got invalid security type")
+	}
+	s := &WirelessSecurity{Band: band, Enabled: secType == pskSecurityType, Passphrase: para.strAt(3)}
+	if s.Version, err = lookupCode(para, 1, securityVersions, "WPA version"); err != nil {
+		return nil, err
+	}
+	if s.Cipher, err = lookupCode(para, 2, securityCiphers, "cipher"); err != nil {
+		return nil, err
+	}
+	interval, err := para.intAt(4)
+	if err != nil {
+		return nil, errors.Wrap(err, "got invalid group key update period")
+	}
+	s.GroupKeyUpdatePeriod = time.Duration(interval) * time.Second
+	return s, nil
+}
+
+// SetWirelessSecurity wraps SetWirelessSecurityContext using context.Background.
+func (c *Client) SetWirelessSecurity(s *WirelessSecurity) error {
+	return c.SetWirelessSecurityContext(context.Background(), s)
+}
+
+// SetWirelessSecurityContext changes the security settings of the router's radio
+// for s.Band to s. An error is returned if the settings are invalid or cannot be
+// applied.
+func (c *Client) SetWirelessSecurityContext(ctx context.Context, s *WirelessSecurity) error {
+	if s == nil {
+		return errors.New("got nil wireless security settings (want non-nil settings)")
+	}
+	if err := s.Validate(); err != nil {
+		return errors.Wrap(err, "got invalid wireless security settings")
+	}
+
+	q := url.Values{}
+	q.Set("secType", "0")
+	if s.Enabled {
+		q.Set("secType", strconv.Itoa(pskSecurityType))
+	}
+	q.Set("pskSecOpt", strconv.Itoa(codeOf(securityVersions, s.Version)))
+	q.Set("pskCipher", strconv.Itoa(codeOf(securityCiphers, s.Cipher)))
+	q.Set("pskSecret", s.Passphrase)
+	q.Set("interval", strconv.Itoa(int(s.GroupKeyUpdatePeriod/time.Second)))
+	q.Set("Save", "Save")
+
+	_, err := c.getPage(ctx, s.Band.page(wirelessSecurityPage), q, "set "+s.Band.String()+" wireless security")
+	return err
+}
+
+// Validate checks that s describes security settings the router accepts. The
+// passphrase must be 8 to 63 printable ASCII characters or 64 hex digits.
+func (s *WirelessSecurity) Validate() error {
+	if s.Band != Band24GHz && s.Band != Band5GHz {
+		return fmt.Errorf("got band %s (want 2.4GHz or 5GHz)", s.Band)
+	}
+	if !s.Enabled {
+		return nil
+	}
+	if codeOf(securityVersions, s.Version) == 0 {
+		return fmt.Errorf("got WPA version %q (want one of %s)",
+			s.Version, strings.Join(names(securityVersions), ", "))
+	}
+	if codeOf(securityCiphers, s.Cipher) == 0 {
+		return fmt.Errorf("got cipher %q (want one of %s)",
+			s.Cipher, strings.Join(names(securityCiphers), ", "))
+	}
+	if err := validatePassphrase(s.Passphrase); err != nil {
+		return err
+	}
+	if s.GroupKeyUpdatePeriod != 0 &&
+		(s.GroupKeyUpdatePeriod < minGroupKeyUpdatePeriod || s.GroupKeyUpdatePeriod%time.Second != 0) {
+		return fmt.Errorf("got group key update period %s (want 0 or whole seconds of at least %s)",
+			s.GroupKeyUpdatePeriod, minGroupKeyUpdatePeriod)
+	}
+	return nil
+}
+
+func validatePassphrase(p string) error {
+	if len(p) == 64 {
+		for _, r := range p {
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return errors.New("got 64 character passphrase with non-hex characters (want 64 hex digits)")
+			}
+		}
+		return nil
+	}
+	if len(p) < minPassphraseLength || len(p) > maxPassphraseLength {
+		return fmt.Errorf("got passphrase of %d characters (want %d to %d characters)",
+			len(p), minPassphraseLength, maxPassphraseLength)
+	}
+	for _, r := range p {
+		if r < ' ' || r > '~' {
+			return fmt.Errorf("got passphrase with character %q (want printable ASCII characters)", r)
+		}
+	}
+	return nil
+}
+
+// GeneratePassphrase returns a random passphrase of the given length made of the
+// characters of charset, which is one of digits, hex, alpha, alnum or printable.
+// The length must be between 8 and 63 characters.
+func GeneratePassphrase(length int, charset string) (string, error) {
+	chars, ok := passphraseCharsets[charset]
+	if !ok {
+		return "", fmt.Errorf("got character set %q (want one of digits, hex, alpha, alnum, printable)", charset)
+	}
+	if length < minPassphraseLength || length > maxPassphraseLength {
+		return "", fmt.Errorf("got passphrase length %d (want %d to %d)",
+			length, minPassphraseLength, maxPassphraseLength)
+	}
+
+	p := make([]byte, length)
+	max := big.NewInt(int64(len(chars)))
+	for i := range p {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", errors.Wrap(err, "got error generating random passphrase")
+		}
+		p[i] = chars[n.Int64()]
+	}
+	return string(p), nil
+}
+
+// WiFiQRPayload returns the payload of a QR code that lets phones join the WPA
+// network with the given SSID and passphrase, in the WIFI:T:WPA;S:...;P:...;;
+// format.
+func WiFiQRPayload(ssid, passphrase string, hidden bool) string {
+	escape := strings.NewReplacer(`\`, `\\`, `;`, `\;`, `,`, `\,`, `:`, `\:`, `"`, `\"`)
+	payload := "WIFI:T:WPA;S:" + escape.Replace(ssid) + ";P:" + escape.Replace(passphrase) + ";"
+	if hidden {
+		payload += "H:true;"
+	}
+	return payload + ";"
+}
@@ -0,0 +1,262 @@
+package archerc9v1
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var validWirelessSecurityPage = `<script type="text/javascript">
+var wlanSecPara = new Array(
+3,
+3,
+3,
+"old passphrase",
+0,
+0,0 );
+</script>`
+
+func TestClient_GetWirelessSecurity(t *testing.T) {
+	testCases := []*struct {
+		description string
+		input       RoundTripFunc
+		expected    *WirelessSecurity
+		expectError bool
+	}{
+		{
+			description: "404 status code in response",
+			input:       servePages(nil),
+			expectError: true,
+		},
+		{
+			description: "Unknown cipher code",
+			input: servePages(map[string]string{"/userRpm/WlanSecurityRpm_5g.htm": `
+var wlanSecPara = new Array(3, 3, 7, "old passphrase", 0, 0,0 );`}),
+			expectError: true,
+		},
+		{
+			description: "Open network",
+			input: servePages(map[string]string{"/userRpm/WlanSecurityRpm_5g.htm": `
+var wlanSecPara = new Array(0, 1, 1, "", 3600, 0,0 );`}),
+			expected: &WirelessSecurity{
+				Band:                 Band5GHz,
+				Enabled:              false,
+				Version:              "auto",
+				Cipher:               "auto",
+				GroupKeyUpdatePeriod: time.Hour,
+			},
+		},
+		{
+			description: "WPA2-PSK network",
+			input:       servePages(map[string]string{"/userRpm/WlanSecurityRpm_5g.htm": validWirelessSecurityPage}),
+			expected: &WirelessSecurity{
+				Band:       Band5GHz,
+				Enabled:    true,
+				Version:    "wpa2",
+				Cipher:     "aes",
+				Passphrase: "old passphrase",
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		client = newPageTestClient(tt.input)
+		got, err := client.GetWirelessSecurity(Band5GHz)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetWirelessSecurity(5GHz) did not return an expected error",
+					tt.description, client)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetWirelessSecurity(5GHz) returned an unexpected error: %v",
+					tt.description, client, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.GetWirelessSecurity(5GHz) returned %+v, want %+v",
+					tt.description, client, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_SetWirelessSecurity(t *testing.T) {
+	var saved url.Values
+	client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		saved = r.URL.Query()
+		return servePages(map[string]string{"/" + wirelessSecurityPage: validWirelessSecurityPage})(r)
+	})
+
+	invalid := &WirelessSecurity{Band: Band24GHz, Enabled: true, Version: "wpa2", Cipher: "aes", Passphrase: "short"}
+	if err := client.SetWirelessSecurity(invalid); err == nil || saved != nil {
+		t.Fatalf("FAIL: Short passphrase\n\t%v.SetWirelessSecurity(%+v) returned %v and submitted %v, want an error and nothing submitted",
+			client, invalid, err, saved)
+	}
+	t.Logf("PASS: Short passphrase")
+
+	s := &WirelessSecurity{
+		Band:                 Band24GHz,
+		Enabled:              true,
+		Version:              "wpa2",
+		Cipher:               "aes",
+		Passphrase:           "new passphrase",
+		GroupKeyUpdatePeriod: time.Hour,
+	}
+	if err := client.SetWirelessSecurity(s); err != nil {
+		t.Fatalf("FAIL: Valid settings\n\t%v.SetWirelessSecurity(%+v) returned an unexpected error: %v",
+			client, s, err)
+	}
+	expected := url.Values{
+		"secType":   {"3"},
+		"pskSecOpt": {"3"},
+		"pskCipher": {"3"},
+		"pskSecret": {"new passphrase"},
+		"interval":  {"3600"},
+		"Save":      {"Save"},
+	}
+	if !reflect.DeepEqual(saved, expected) {
+		t.Fatalf("FAIL: Valid settings\n\t%v.SetWirelessSecurity(%+v) submitted %v, want %v",
+			client, s, saved, expected)
+	}
+	t.Logf("PASS: Valid settings")
+}
+
+func TestWirelessSecurity_Validate(t *testing.T) {
+	testCases := []*struct {
+		description string
+		modify      func(s *WirelessSecurity)
+		expectError bool
+	}{
+		{description: "Valid settings", modify: func(s *WirelessSecurity) {}},
+		{
+			description: "Open network ignores other settings",
+			modify: func(s *WirelessSecurity) {
+				s.Enabled = false
+				s.Passphrase = ""
+			},
+		},
+		{
+			description: "64 hex digit passphrase",
+			modify:      func(s *WirelessSecurity) { s.Passphrase = strings.Repeat("aF", 32) },
+		},
+		{
+			description: "64 character non-hex passphrase",
+			modify:      func(s *WirelessSecurity) { s.Passphrase = strings.Repeat("x", 64) },
+			expectError: true,
+		},
+		{
+			description: "Passphrase too short",
+			modify:      func(s *WirelessSecurity) { s.Passphrase = "1234567" },
+			expectError: true,
+		},
+		{
+			description: "Non-ASCII passphrase",
+			modify:      func(s *WirelessSecurity) { s.Passphrase = "pässphrase" },
+			expectError: true,
+		},
+		{
+			description: "Unknown WPA version",
+			modify:      func(s *WirelessSecurity) { s.Version = "wpa3" },
+			expectError: true,
+		},
+		{
+			description: "Group key update period too short",
+			modify:      func(s *WirelessSecurity) { s.GroupKeyUpdatePeriod = 10 * time.Second },
+			expectError: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		s := &WirelessSecurity{Band: Band24GHz, Enabled: true, Version: "auto", Cipher: "auto", Passphrase: "passphrase"}
+		tt.modify(s)
+		err := s.Validate()
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%+v.Validate() did not return an expected error", tt.description, s)
+			}
+		} else if err != nil {
+			t.Fatalf("FAIL: %s\n\t%+v.Validate() returned an unexpected error: %v", tt.description, s, err)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestGeneratePassphrase(t *testing.T) {
+	for charset, chars := range passphraseCharsets {
+		p, err := GeneratePassphrase(63, charset)
+		if err != nil {
+			t.Fatalf("FAIL: %s\n\tGeneratePassphrase(63, %s) returned an unexpected error: %v", charset, charset, err)
+		}
+		if len(p) != 63 {
+			t.Fatalf("FAIL: %s\n\tGeneratePassphrase(63, %s) returned %q of length %d, want length 63",
+				charset, charset, p, len(p))
+		}
+		for _, r := range p {
+			if !strings.ContainsRune(chars, r) {
+				t.Fatalf("FAIL: %s\n\tGeneratePassphrase(63, %s) returned %q with character %q outside of the set",
+					charset, charset, p, r)
+			}
+		}
+		if err = validatePassphrase(p); err != nil {
+			t.Fatalf("FAIL: %s\n\tGeneratePassphrase(63, %s) returned invalid passphrase %q: %v", charset, charset, p, err)
+		}
+		t.Logf("PASS: %s", charset)
+	}
+
+	errorCases := []*struct {
+		description string
+		length      int
+		charset     string
+	}{
+		{"Too short", 7, "alnum"},
+		{"Too long", 64, "alnum"},
+		{"Unknown character set", 16, "emoji"},
+	}
+	for _, tt := range errorCases {
+		if _, err := GeneratePassphrase(tt.length, tt.charset); err == nil {
+			t.Fatalf("FAIL: %s\n\tGeneratePassphrase(%d, %s) did not return an expected error",
+				tt.description, tt.length, tt.charset)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestWiFiQRPayload(t *testing.T) {
+	testCases := []*struct {
+		description, ssid, passphrase string
+		hidden                        bool
+		expected                      string
+	}{
+		{
+			description: "Plain SSID and passphrase",
+			ssid:        "HomeNet",
+			passphrase:  "passphrase",
+			expected:    "WIFI:T:WPA;S:HomeNet;P:passphrase;;",
+		},
+		{
+			description: "Special characters",
+			ssid:        `Home;Net`,
+			passphrase:  `a:b,c"d\e`,
+			expected:    `WIFI:T:WPA;S:Home\;Net;P:a\:b\,c\"d\\e;;`,
+		},
+		{
+			description: "Hidden network",
+			ssid:        "HomeNet",
+			passphrase:  "passphrase",
+			hidden:      true,
+			expected:    "WIFI:T:WPA;S:HomeNet;P:passphrase;H:true;;",
+		},
+	}
+
+	for _, tt := range testCases {
+		if got := WiFiQRPayload(tt.ssid, tt.passphrase, tt.hidden); got != tt.expected {
+			t.Fatalf("FAIL: %s\n\tWiFiQRPayload(%q, %q, %t) returned %q, want %q",
+				tt.description, tt.ssid, tt.passphrase, tt.hidden, got, tt.expected)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
@@ -0,0 +1,251 @@
+package archerc9v1
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var (
+	validWirelessPage = `<script type="text/javascript">
+var wlanPara = new Array(
+"HomeNet",
+1,
+1,
+6,
+5,
+3,
+1,
+0,0 );
+</script>`
+	valid5GHzWirelessPage = `<script type="text/javascript">
+var wlanPara = new Array(
+"HomeNet_5G",
+1,
+0,
+44,
+5,
+4,
+2,
+0,0 );
+</script>`
+	validWirelessPages = map[string]string{
+		"/" + wirelessSettingsPage:                validWirelessPage,
+		"/" + Band5GHz.page(wirelessSettingsPage): valid5GHzWirelessPage,
+	}
+)
+
+func TestParseBand(t *testing.T) {
+	testCases := []*struct {
+		input       string
+		expected    Band
+		expectError bool
+	}{
+		{input: "2.4g", expected: Band24GHz},
+		{input: "2.4GHz", expected: Band24GHz},
+		{input: "2g", expected: Band24GHz},
+		{input: "5g", expected: Band5GHz},
+		{input: "5GHz", expected: Band5GHz},
+		{input: "6g", expectError: true},
+		{input: "", expectError: true},
+	}
+
+	for _, tt := range testCases {
+		got, err := ParseBand(tt.input)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tParseBand(%q) did not return an expected error", tt.input, tt.input)
+			}
+		} else if err != nil || got != tt.expected {
+			t.Fatalf("FAIL: %s\n\tParseBand(%q) returned (%s, %v), want %s", tt.input, tt.input, got, err, tt.expected)
+		}
+		t.Logf("PASS: %s", tt.input)
+	}
+}
+
+func TestClient_GetWirelessSettings(t *testing.T) {
+	testCases := []*struct {
+		description string
+		band        Band
+		input       RoundTripFunc
+		expected    *WirelessSettings
+		expectError bool
+	}{
+		{
+			description: "404 status code in response",
+			band:        Band24GHz,
+			input:       servePages(nil),
+			expectError: true,
+		},
+		{
+			description: "Unknown mode code",
+			band:        Band24GHz,
+			input: servePages(map[string]string{"/" + wirelessSettingsPage: `
+var wlanPara = new Array("HomeNet", 1, 1, 6, 9, 3, 1, 0,0 );`}),
+			expectError: true,
+		},
+		{
+			description: "Valid 2.4GHz response",
+			band:        Band24GHz,
+			input:       servePages(validWirelessPages),
+			expected: &WirelessSettings{
+				Band:          Band24GHz,
+				RadioEnabled:  true,
+				SSID:          "HomeNet",
+				BroadcastSSID: true,
+				Channel:       6,
+				ChannelWidth:  40,
+				Mode:          "bgn",
+				TransmitPower: "high",
+			},
+		},
+		{
+			description: "Valid 5GHz response",
+			band:        Band5GHz,
+			input:       servePages(validWirelessPages),
+			expected: &WirelessSettings{
+				Band:          Band5GHz,
+				RadioEnabled:  true,
+				SSID:          "HomeNet_5G",
+				BroadcastSSID: false,
+				Channel:       44,
+				ChannelWidth:  80,
+				Mode:          "anac",
+				TransmitPower: "middle",
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		client = newPageTestClient(tt.input)
+		got, err := client.GetWirelessSettings(tt.band)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetWirelessSettings(%s) did not return an expected error",
+					tt.description, client, tt.band)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\t%v.GetWirelessSettings(%s) returned an unexpected error: %v",
+					tt.description, client, tt.band, err)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Fatalf("FAIL: %s\n\t%v.GetWirelessSettings(%s) returned %+v, want %+v",
+					tt.description, client, tt.band, got, tt.expected)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestClient_SetWirelessSettings(t *testing.T) {
+	var saved url.Values
+	var savedPath string
+	client = newPageTestClient(func(r *http.Request) (*http.Response, error) {
+		saved, savedPath = r.URL.Query(), r.URL.Path
+		return servePages(validWirelessPages)(r)
+	})
+	s := &WirelessSettings{
+		Band:          Band5GHz,
+		RadioEnabled:  true,
+		SSID:          "HomeNet_5G",
+		BroadcastSSID: true,
+		Channel:       149,
+		ChannelWidth:  80,
+		Mode:          "ac",
+		TransmitPower: "low",
+	}
+	if err := client.SetWirelessSettings(s); err != nil {
+		t.Fatalf("FAIL: Valid settings\n\t%v.SetWirelessSettings(%+v) returned an unexpected error: %v",
+			client, s, err)
+	}
+	expected := url.Values{
+		"ssid1":     {"HomeNet_5G"},
+		"ap":        {"1"},
+		"broadcast": {"1"},
+		"channel":   {"149"},
+		"mode":      {"3"},
+		"chanWidth": {"4"},
+		"power":     {"3"},
+		"Save":      {"Save"},
+	}
+	if savedPath != "/userRpm/WlanNetworkRpm_5g.htm" || !reflect.DeepEqual(saved, expected) {
+		t.Fatalf("FAIL: Valid settings\n\t%v.SetWirelessSettings(%+v) submitted %s?%v, want %s?%v",
+			client, s, savedPath, saved, "/userRpm/WlanNetworkRpm_5g.htm", expected)
+	}
+	t.Logf("PASS: Valid settings")
+}
+
+func TestWirelessSettings_Validate(t *testing.T) {
+	testCases := []*struct {
+		description string
+		modify      func(s *WirelessSettings)
+		expectError bool
+	}{
+		{
+			description: "Valid settings",
+			modify:      func(s *WirelessSettings) {},
+		},
+		{
+			description: "Automatic channel and width",
+			modify: func(s *WirelessSettings) {
+				s.Channel = 0
+				s.ChannelWidth = 0
+			},
+		},
+		{
+			description: "Empty SSID",
+			modify:      func(s *WirelessSettings) { s.SSID = "" },
+			expectError: true,
+		},
+		{
+			description: "SSID too long",
+			modify:      func(s *WirelessSettings) { s.SSID = "0123456789012345678901234567890123" },
+			expectError: true,
+		},
+		{
+			description: "5GHz channel on 2.4GHz",
+			modify:      func(s *WirelessSettings) { s.Channel = 44 },
+			expectError: true,
+		},
+		{
+			description: "80MHz width on 2.4GHz",
+			modify:      func(s *WirelessSettings) { s.ChannelWidth = 80 },
+			expectError: true,
+		},
+		{
+			description: "5GHz mode on 2.4GHz",
+			modify:      func(s *WirelessSettings) { s.Mode = "ac" },
+			expectError: true,
+		},
+		{
+			description: "Unknown transmit power",
+			modify:      func(s *WirelessSettings) { s.TransmitPower = "max" },
+			expectError: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		s := &WirelessSettings{
+			Band:          Band24GHz,
+			RadioEnabled:  true,
+			SSID:          "HomeNet",
+			BroadcastSSID: true,
+			Channel:       6,
+			ChannelWidth:  40,
+			Mode:          "bgn",
+			TransmitPower: "high",
+		}
+		tt.modify(s)
+		err := s.Validate()
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\t%+v.Validate() did not return an expected error", tt.description, s)
+			}
+		} else if err != nil {
+			t.Fatalf("FAIL: %s\n\t%+v.Validate() returned an unexpected error: %v", tt.description, s, err)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
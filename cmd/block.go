@@ -0,0 +1,74 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var blockName string
+
+// blockCmd represents the block command
+var blockCmd = &cobra.Command{
+	Use:   "block (MAC | IP | HOST_NAME)",
+	Short: "blocks a node from the router and the Internet",
+	Long: `block looks up the given MAC address, IP address or host name among the currently
+connected wired and wireless clients and adds the node to the router's access control
+blacklist, turning access control on if needed. A MAC address of a node that is not
+connected may also be given.
+
+Examples:
+  tplink block 192.168.0.123
+  tplink block noisy-laptop
+  tplink block 12:34:56:aa:bb:cc --name "noisy laptop"`,
+	Args:             cobra.ExactArgs(1),
+	PersistentPreRun: newClient,
+	Run: func(cmd *cobra.Command, args []string) {
+		mac, name := args[0], ""
+		conn, err := client.FindConnectionContext(ctx, args[0])
+		if err == nil {
+			mac, name = conn.MacAddress, conn.Name
+		} else if _, macErr := archerc9v1.NormalizeMAC(args[0]); macErr != nil {
+			fatalf("got error finding node to block: %v", err)
+		}
+		if cmd.Flags().Changed("name") {
+			name = blockName
+		}
+		if name == "" {
+			name = mac
+		}
+
+		if err = client.BlockDeviceContext(ctx, mac, name); err != nil {
+			fatalf("got error blocking %s: %v", mac, err)
+		}
+		fmt.Printf("blocked %s (%s)!\n", name, mac)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(blockCmd)
+	addRouterFlags(blockCmd)
+	blockCmd.Flags().StringVar(&blockName, "name", "", "name of the blacklist entry (default the node's host name)")
+}
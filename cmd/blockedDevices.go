@@ -0,0 +1,53 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// blockedDevicesCmd represents the blockedDevices command
+var blockedDevicesCmd = &cobra.Command{
+	Use:   "blockedDevices",
+	Short: "displays the nodes on the access control blacklist",
+	Long: `blockedDevices queries the wifi router to get its access control blacklist and prints
+out the MAC address and name of each node blocked with the block command.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		blocked, err := client.ListBlockedDevicesContext(ctx)
+		if err != nil {
+			fatalf("got error retrieving blocked devices (want a []*archerc9v1.BlockedDevice): %v", err)
+		}
+		if len(blocked) == 0 {
+			fmt.Println("No blocked devices found")
+		}
+		fmt.Printf("%-22s%-15s\n", "MAC_ADDRESS", "NAME")
+		for _, d := range blocked {
+			fmt.Printf("%-22s%-15s\n", d.MacAddress, d.Name)
+		}
+	},
+}
+
+func init() {
+	listCmd.AddCommand(blockedDevicesCmd)
+}
@@ -0,0 +1,72 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var configBackupOutput string
+
+// configBackupCmd represents the config backup command
+var configBackupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "saves the router configuration to a file",
+	Long: `backup downloads the router's configuration (config.bin) and saves it to the file
+given by --output, or writes it to standard output if the file is "-". The file can
+be restored with "tplink config restore".
+
+Examples:
+  tplink config backup -o archer-c9.bin
+  tplink config backup -o - | gzip > archer-c9.bin.gz`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if configBackupOutput == "-" {
+			if err := client.BackupConfigContext(ctx, os.Stdout); err != nil {
+				fatalf("got error backing up router configuration: %v", err)
+			}
+			return
+		}
+
+		f, err := os.Create(configBackupOutput)
+		if err != nil {
+			fatalf("got error creating backup file: %v", err)
+		}
+		if err = client.BackupConfigContext(ctx, f); err != nil {
+			f.Close()
+			os.Remove(configBackupOutput)
+			fatalf("got error backing up router configuration: %v", err)
+		}
+		if err = f.Close(); err != nil {
+			fatalf("got error writing backup file: %v", err)
+		}
+		fmt.Printf("router configuration saved to %s!\n", configBackupOutput)
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configBackupCmd)
+	configBackupCmd.Flags().StringVarP(&configBackupOutput, "output", "o", "config.bin", `file to save the configuration to ("-" for standard output)`)
+}
@@ -0,0 +1,82 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	configRestoreYes         bool
+	configRestoreWaitTimeout time.Duration
+)
+
+// configRestoreCmd represents the config restore command
+var configRestoreCmd = &cobra.Command{
+	Use:   "restore <file>",
+	Short: "restores the router configuration from a file",
+	Long: `restore uploads a configuration file saved by "tplink config backup" to the router,
+replacing all of its current settings. The router reboots to apply the configuration,
+and restore waits for it to come back up unless --wait-timeout is 0. Note that the
+restored configuration may change the router's address and admin credentials.
+
+Examples:
+  tplink config restore archer-c9.bin
+  tplink config restore archer-c9.bin --yes --wait-timeout 5m`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		f, err := os.Open(args[0])
+		if err != nil {
+			fatalf("got error opening configuration file: %v", err)
+		}
+		defer f.Close()
+
+		if !configRestoreYes && !confirm("Replace all router settings with "+args[0]+" and reboot the router?") {
+			fmt.Println("restore cancelled!")
+			return
+		}
+		if err = client.RestoreConfigContext(ctx, f); err != nil {
+			fatalf("got error restoring router configuration: %v", err)
+		}
+		if configRestoreWaitTimeout == 0 {
+			fmt.Println("router configuration restored, the router is rebooting!")
+			return
+		}
+
+		fmt.Println("router configuration restored, waiting for the router to reboot ...")
+		downtime, err := client.WaitForRestartContext(ctx, configRestoreWaitTimeout)
+		if err != nil {
+			fatalf("got error waiting for the router to reboot: %v", err)
+		}
+		fmt.Printf("router is back up after %s!\n", downtime.Round(time.Second))
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configRestoreCmd)
+	configRestoreCmd.Flags().BoolVarP(&configRestoreYes, "yes", "y", false, "restore without asking for confirmation")
+	configRestoreCmd.Flags().DurationVar(&configRestoreWaitTimeout, "wait-timeout", 3*time.Minute, "how long to wait for the router to reboot (0 to not wait)")
+}
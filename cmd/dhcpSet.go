@@ -0,0 +1,94 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	dhcpEnabled                         bool
+	dhcpStartIP, dhcpEndIP, dhcpGateway string
+	dhcpDomain                          string
+	dhcpLeaseTime                       time.Duration
+	dhcpDNSServers                      []string
+)
+
+// dhcpSetCmd represents the dhcp set command
+var dhcpSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "changes the DHCP server settings",
+	Long: `set changes the settings of the router's DHCP server. Only the settings given as
+flags are changed, all other settings keep their current values. The address pool and
+gateway must lie inside the router's LAN subnet.
+
+Example:
+  tplink dhcp set --start 192.168.0.100 --end 192.168.0.199 --lease 2h --dns 1.1.1.1,8.8.8.8`,
+	Run: func(cmd *cobra.Command, args []string) {
+		s, err := client.GetDHCPSettingsContext(ctx)
+		if err != nil {
+			fatalf("got error retrieving current DHCP settings (want a *archerc9v1.DHCPSettings): %v", err)
+		}
+
+		flags := cmd.Flags()
+		if flags.Changed("enable") {
+			s.Enabled = dhcpEnabled
+		}
+		if flags.Changed("start") {
+			s.StartIP = dhcpStartIP
+		}
+		if flags.Changed("end") {
+			s.EndIP = dhcpEndIP
+		}
+		if flags.Changed("lease") {
+			s.LeaseTime = dhcpLeaseTime
+		}
+		if flags.Changed("gateway") {
+			s.Gateway = dhcpGateway
+		}
+		if flags.Changed("domain") {
+			s.Domain = dhcpDomain
+		}
+		if flags.Changed("dns") {
+			s.DNSServers = dhcpDNSServers
+		}
+
+		if err = client.SetDHCPSettingsContext(ctx, s); err != nil {
+			fatalf("got error changing DHCP settings: %v", err)
+		}
+		fmt.Println("DHCP settings updated!")
+	},
+}
+
+func init() {
+	dhcpCmd.AddCommand(dhcpSetCmd)
+	dhcpSetCmd.Flags().BoolVar(&dhcpEnabled, "enable", true, "enable (true) or disable (false) the DHCP server")
+	dhcpSetCmd.Flags().StringVar(&dhcpStartIP, "start", "", "first address of the DHCP address pool")
+	dhcpSetCmd.Flags().StringVar(&dhcpEndIP, "end", "", "last address of the DHCP address pool")
+	dhcpSetCmd.Flags().DurationVar(&dhcpLeaseTime, "lease", 0, "lease time in whole minutes, e.g. 2h or 90m")
+	dhcpSetCmd.Flags().StringVar(&dhcpGateway, "gateway", "", "default gateway handed out to clients (empty to unset)")
+	dhcpSetCmd.Flags().StringVar(&dhcpDomain, "domain", "", "domain name handed out to clients")
+	dhcpSetCmd.Flags().StringSliceVar(&dhcpDNSServers, "dns", nil, "up to two comma separated DNS servers handed out to clients")
+}
@@ -0,0 +1,59 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// dhcpShowCmd represents the dhcp show command
+var dhcpShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "displays the DHCP server settings",
+	Long: `show queries the wifi router to get the settings of its DHCP server and prints out
+whether the server is enabled, the address pool, the lease time, the default gateway
+and the DNS servers handed out to clients.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		s, err := client.GetDHCPSettingsContext(ctx)
+		if err != nil {
+			fatalf("got error retrieving DHCP settings (want a *archerc9v1.DHCPSettings): %v", err)
+		}
+		state := "disabled"
+		if s.Enabled {
+			state = "enabled"
+		}
+		fmt.Printf("%-15s%s\n", "DHCP_SERVER", state)
+		fmt.Printf("%-15s%s\n", "START_IP", s.StartIP)
+		fmt.Printf("%-15s%s\n", "END_IP", s.EndIP)
+		fmt.Printf("%-15s%s\n", "LEASE_TIME", s.LeaseTime)
+		fmt.Printf("%-15s%s\n", "GATEWAY", s.Gateway)
+		fmt.Printf("%-15s%s\n", "DOMAIN", s.Domain)
+		fmt.Printf("%-15s%s\n", "DNS_SERVERS", strings.Join(s.DNSServers, ","))
+	},
+}
+
+func init() {
+	dhcpCmd.AddCommand(dhcpShowCmd)
+}
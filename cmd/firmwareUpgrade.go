@@ -0,0 +1,120 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	firmwareForce, firmwareYes bool
+	firmwareWaitTimeout        time.Duration
+)
+
+// firmwareUpgradeCmd represents the firmware upgrade command
+var firmwareUpgradeCmd = &cobra.Command{
+	Use:   "upgrade <file>",
+	Short: "installs a firmware image on the router",
+	Long: `upgrade checks that the file has the size of an Archer C9 V1 firmware image and
+a TP Link header, compares the image's version with the router's current firmware
+version and uploads the image. The router checks that the image is built for its
+hardware itself. Downgrades, and images whose version cannot be compared, are
+refused unless --force is given. The router then flashes the image and reboots, and
+upgrade waits for it to come back up and reports the firmware version it runs,
+unless --wait-timeout is 0. Do not power off the router during the upgrade.
+
+Examples:
+  tplink firmware upgrade ArcherC9v1_en_3_17_0_up_boot.bin
+  tplink firmware upgrade old.bin --force --yes`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		data, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			fatalf("got error reading firmware image: %v", err)
+		}
+		img, err := archerc9v1.ParseFirmwareImage(data)
+		if err != nil {
+			fatalf("got invalid firmware image: %v", err)
+		}
+
+		status, err := client.GetStatusContext(ctx)
+		if err != nil {
+			fatalf("got error retrieving current firmware version: %v", err)
+		}
+		fmt.Printf("%-12s%s\n", "CURRENT", status.FirmwareVersion)
+		imgVersion := img.Version
+		if imgVersion == "" {
+			imgVersion = "unknown"
+		}
+		fmt.Printf("%-12s%s\n", "IMAGE", imgVersion)
+		cmp, err := archerc9v1.CompareFirmwareVersions(img.Version, status.FirmwareVersion)
+		compared := err == nil
+		switch {
+		case err != nil && !firmwareForce:
+			fatalf("got error comparing firmware versions, use --force to upgrade anyway: %v", err)
+		case cmp < 0 && !firmwareForce:
+			fatalf("got image older than the current firmware (want a newer one), use --force to downgrade")
+		}
+
+		if !firmwareYes && !confirm("Install "+imgVersion+" on the router? Do not power it off until it is back up.") {
+			fmt.Println("upgrade cancelled!")
+			return
+		}
+		if _, err = client.FirmwareUpgradeContext(ctx, bytes.NewReader(data)); err != nil {
+			fatalf("got error upgrading firmware: %v", err)
+		}
+		if firmwareWaitTimeout == 0 {
+			fmt.Println("firmware uploaded, the router is rebooting!")
+			return
+		}
+
+		fmt.Println("firmware uploaded, waiting for the router to flash it and reboot ...")
+		downtime, err := client.WaitForRestartContext(ctx, firmwareWaitTimeout)
+		if err != nil {
+			fatalf("got error waiting for the router to reboot: %v", err)
+		}
+		if status, err = client.GetStatusContext(ctx); err != nil {
+			fatalf("got error retrieving new firmware version: %v", err)
+		}
+		// An image whose version could not be read leaves nothing to check the
+		// router's new version against.
+		if compared {
+			if cmp, err = archerc9v1.CompareFirmwareVersions(status.FirmwareVersion, img.Version); err != nil || cmp != 0 {
+				fatalf("got firmware version %s after the upgrade (want %s)", status.FirmwareVersion, img.Version)
+			}
+		}
+		fmt.Printf("router is back up after %s running firmware %s!\n", downtime.Round(time.Second), status.FirmwareVersion)
+	},
+}
+
+func init() {
+	firmwareCmd.AddCommand(firmwareUpgradeCmd)
+	firmwareUpgradeCmd.Flags().BoolVar(&firmwareForce, "force", false, "install the image even if it is older than the current firmware")
+	firmwareUpgradeCmd.Flags().BoolVarP(&firmwareYes, "yes", "y", false, "upgrade without asking for confirmation")
+	firmwareUpgradeCmd.Flags().DurationVar(&firmwareWaitTimeout, "wait-timeout", 5*time.Minute, "how long to wait for the router to reboot (0 to not wait)")
+}
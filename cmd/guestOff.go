@@ -0,0 +1,52 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// guestOffCmd represents the guest off command
+var guestOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "turns the guest network off",
+	Long:  `off disables the guest network on the given band(s), keeping its other settings.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		for _, band := range parseBands(guestBand) {
+			g, err := client.GetGuestNetworkContext(ctx, band)
+			if err != nil {
+				fatalf("got error retrieving %s guest network (want a *archerc9v1.GuestNetwork): %v", band, err)
+			}
+			g.Enabled = false
+			if err = client.SetGuestNetworkContext(ctx, g); err != nil {
+				fatalf("got error turning off %s guest network: %v", band, err)
+			}
+			fmt.Printf("%s guest network %s is off!\n", band, g.SSID)
+		}
+	},
+}
+
+func init() {
+	guestCmd.AddCommand(guestOffCmd)
+}
@@ -0,0 +1,114 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	guestSSID, guestPassphrase       string
+	guestSecurity                    string
+	guestAllowLAN, guestSeeEachOther bool
+	guestUpload, guestDownload       int
+)
+
+// guestOnCmd represents the guest on command
+var guestOnCmd = &cobra.Command{
+	Use:   "on",
+	Short: "turns the guest network on",
+	Long: `on enables the guest network on the given band(s). Only the settings given as flags
+are changed, all other settings keep their current values. Giving --passphrase secures
+the guest network with WPA2-Personal, --security open (or none) makes it an open
+network again, and giving --upload or --download turns on bandwidth control.
+
+Examples:
+  tplink guest on
+  tplink guest on --band 2.4g --ssid Visitors --passphrase "welcome to the office" --download 4096
+  tplink guest on --security open`,
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		var secure bool
+		if flags.Changed("security") {
+			switch guestSecurity {
+			case "wpa2":
+				secure = true
+			case "open", "none":
+				if flags.Changed("passphrase") {
+					fatalf("got --passphrase with --security %s (want no passphrase for an open network)", guestSecurity)
+				}
+			default:
+				fatalf("got guest network security %q (want wpa2, open or none)", guestSecurity)
+			}
+		}
+		for _, band := range parseBands(guestBand) {
+			g, err := client.GetGuestNetworkContext(ctx, band)
+			if err != nil {
+				fatalf("got error retrieving %s guest network (want a *archerc9v1.GuestNetwork): %v", band, err)
+			}
+
+			g.Enabled = true
+			if flags.Changed("ssid") {
+				g.SSID = guestSSID
+			}
+			if flags.Changed("security") {
+				g.SecurityEnabled = secure
+			}
+			if flags.Changed("passphrase") {
+				g.SecurityEnabled = true
+				g.Passphrase = guestPassphrase
+			}
+			if flags.Changed("allow-lan") {
+				g.AllowLANAccess = guestAllowLAN
+			}
+			if flags.Changed("see-each-other") {
+				g.AllowSeeEachOther = guestSeeEachOther
+			}
+			if flags.Changed("upload") {
+				g.BandwidthControl = true
+				g.UploadLimit = guestUpload
+			}
+			if flags.Changed("download") {
+				g.BandwidthControl = true
+				g.DownloadLimit = guestDownload
+			}
+
+			if err = client.SetGuestNetworkContext(ctx, g); err != nil {
+				fatalf("got error turning on %s guest network: %v", band, err)
+			}
+			fmt.Printf("%s guest network %s is on!\n", band, g.SSID)
+		}
+	},
+}
+
+func init() {
+	guestCmd.AddCommand(guestOnCmd)
+	guestOnCmd.Flags().StringVar(&guestSSID, "ssid", "", "guest network name (SSID)")
+	guestOnCmd.Flags().StringVar(&guestPassphrase, "passphrase", "", "WPA2-Personal passphrase of the guest network")
+	guestOnCmd.Flags().StringVar(&guestSecurity, "security", "", "guest network security: wpa2, or open (none) for no security")
+	guestOnCmd.Flags().BoolVar(&guestAllowLAN, "allow-lan", false, "allow guests to access the LAN")
+	guestOnCmd.Flags().BoolVar(&guestSeeEachOther, "see-each-other", false, "allow guests to see each other")
+	guestOnCmd.Flags().IntVar(&guestUpload, "upload", 0, "upload bandwidth limit for guests in Kbps")
+	guestOnCmd.Flags().IntVar(&guestDownload, "download", 0, "download bandwidth limit for guests in Kbps")
+}
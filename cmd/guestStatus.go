@@ -0,0 +1,61 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// guestStatusCmd represents the guest status command
+var guestStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "displays the guest network settings",
+	Long: `status queries the wifi router to get the settings of its guest network and prints
+out the state, SSID, security, access toggles, and bandwidth limits for each band.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("%-8s%-7s%-33s%-10s%-16s%-11s%-14s\n",
+			"BAND", "STATE", "SSID", "SECURITY", "SEE_EACH_OTHER", "LAN_ACCESS", "UP/DOWN_KBPS")
+		for _, band := range parseBands(guestBand) {
+			g, err := client.GetGuestNetworkContext(ctx, band)
+			if err != nil {
+				fatalf("got error retrieving %s guest network (want a *archerc9v1.GuestNetwork): %v", band, err)
+			}
+			security := "open"
+			if g.SecurityEnabled {
+				security = "wpa2"
+			}
+			limits := "unlimited"
+			if g.BandwidthControl {
+				limits = strconv.Itoa(g.UploadLimit) + "/" + strconv.Itoa(g.DownloadLimit)
+			}
+			fmt.Printf("%-8s%-7s%-33s%-10s%-16s%-11s%-14s\n",
+				band, onOff(g.Enabled), g.SSID, security, onOff(g.AllowSeeEachOther), onOff(g.AllowLANAccess), limits)
+		}
+	},
+}
+
+func init() {
+	guestCmd.AddCommand(guestStatusCmd)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+)
+
+var leasesSortBy string
+
+// leasesCmd represents the leases command
+var leasesCmd = &cobra.Command{
+	Use:   "leases",
+	Short: "displays the DHCP leases and their expiry times",
+	Long: `leases queries the wifi router to get the addresses handed out by its DHCP server and
+prints out the IP address, MAC address, host name, and remaining lease time for each
+lease. Use --sort expiry to list the leases that are about to expire first.`,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if leasesSortBy != "" && leasesSortBy != "expiry" {
+			fatalf("got --sort %q (want expiry)", leasesSortBy)
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		leases, err := client.GetDHCPLeasesContext(ctx)
+		if err != nil {
+			fatalf("got error retrieving DHCP leases (want a []*archerc9v1.Lease): %v", err)
+		}
+		if len(leases) == 0 {
+			fmt.Println("No DHCP leases found")
+		}
+		if leasesSortBy == "expiry" {
+			sort.SliceStable(leases, func(i, j int) bool {
+				if leases[i].Permanent != leases[j].Permanent {
+					return !leases[i].Permanent
+				}
+				return leases[i].Remaining < leases[j].Remaining
+			})
+		}
+		fmt.Printf("%-15s%-22s%-20s%-15s\n", "IP_ADDRESS", "MAC_ADDRESS", "HOST_NAME", "EXPIRES_IN")
+		for _, l := range leases {
+			expires := l.Remaining.String()
+			if l.Permanent {
+				expires = "never"
+			}
+			fmt.Printf("%-15s%-22s%-20s%-15s\n", l.IPAddress, l.MacAddress, l.HostName, expires)
+		}
+	},
+}
+
+func init() {
+	listCmd.AddCommand(leasesCmd)
+	leasesCmd.Flags().StringVar(&leasesSortBy, "sort", "", "sort the leases by the given field (expiry)")
+}
@@ -22,34 +22,20 @@ THE SOFTWARE.
 package cmd
 
 import (
-	"github.com/aculclasure/tplink/archerc9v1"
-	"log"
-
 	"github.com/spf13/cobra"
 )
 
 // listCmd represents the list command
 var listCmd = &cobra.Command{
-	Use:   "list",
-	Short: "lists information about the router",
-	Long: `list information about the router`,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		var err error
-		client, err = archerc9v1.New(userName, password, url, nil, nil)
-		if err != nil {
-			log.Fatalf("got error trying to create new tplinkac9v1.Client: %s", err)
-		}
-	},
+	Use:              "list",
+	Short:            "lists information about the router",
+	Long:             `list information about the router`,
+	PersistentPreRun: newClient,
 	Run: func(cmd *cobra.Command, args []string) {
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(listCmd)
-	listCmd.PersistentFlags().StringVar(&url, "url", "http://192.168.168.1", "router URL (required)")
-	listCmd.MarkFlagRequired("url")
-	listCmd.PersistentFlags().StringVarP(&userName, "user", "U", "admin", "router admin user name (required)")
-	listCmd.MarkFlagRequired("user")
-	listCmd.PersistentFlags().StringVarP(&password, "password", "P", "admin", "router admin password (required)")
-	listCmd.MarkFlagRequired("password")
+	addRouterFlags(listCmd)
 }
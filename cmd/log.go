@@ -0,0 +1,114 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logLevel, logType string
+	logSince, logPoll time.Duration
+	logFollow         bool
+)
+
+// logCmd represents the log command
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "displays the router's system log",
+	Long: `log queries the wifi router to get its system log and prints out the time, type,
+level, and message of each entry, oldest first. The entries can be filtered by minimum
+level (e.g. --level warning also shows errors), by type, and by age. With --follow, the
+log is polled and only new entries are printed until the command is interrupted.
+
+Examples:
+  tplink log --level warning --since 24h
+  tplink log --type ppp --follow`,
+	PersistentPreRun: newClient,
+	Run: func(cmd *cobra.Command, args []string) {
+		maxLevel := archerc9v1.LogDebug
+		if logLevel != "" {
+			var err error
+			if maxLevel, err = archerc9v1.ParseLogLevel(logLevel); err != nil {
+				fatalf("got invalid --level: %v", err)
+			}
+		}
+		if logPoll <= 0 {
+			fatalf("got --interval %s (want more than 0)", logPoll)
+		}
+		var since time.Time
+		if logSince > 0 {
+			since = time.Now().Add(-logSince)
+		}
+
+		seen := make(map[string]bool)
+		for {
+			entries, err := client.GetSystemLogContext(ctx)
+			if err != nil && logFollow && ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				fatalf("got error retrieving system log (want a []*archerc9v1.LogEntry): %v", err)
+			}
+			sort.SliceStable(entries, func(i, j int) bool { return entries[i].Time.Before(entries[j].Time) })
+			current := make(map[string]bool, len(entries))
+			for _, e := range entries {
+				key := e.Time.String() + " " + e.Message
+				current[key] = true
+				if seen[key] {
+					continue
+				}
+				if e.Level > maxLevel || e.Time.Before(since) || (logType != "" && !strings.EqualFold(e.Type, logType)) {
+					continue
+				}
+				fmt.Printf("%-17s%-10s%-11s%s\n", e.Time.Format("Jan _2 15:04:05"), e.Type, e.Level, e.Message)
+			}
+			// Only entries still on the log page can show up again, so forgetting
+			// the others keeps seen from growing while following the log.
+			seen = current
+			if !logFollow {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(logPoll):
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(logCmd)
+	addRouterFlags(logCmd)
+	logCmd.Flags().StringVar(&logLevel, "level", "", "only show entries of this level or more severe (emergency, alert, critical, error, warning, notice, info or debug)")
+	logCmd.Flags().StringVar(&logType, "type", "", "only show entries of this type, e.g. DHCP or PPP")
+	logCmd.Flags().DurationVar(&logSince, "since", 0, "only show entries newer than this, e.g. 1h or 30m")
+	logCmd.Flags().BoolVarP(&logFollow, "follow", "f", false, "keep polling the log and print new entries")
+	logCmd.Flags().DurationVar(&logPoll, "interval", 5*time.Second, "how often to poll the log with --follow")
+}
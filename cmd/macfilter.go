@@ -0,0 +1,45 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// macfilterCmd represents the macfilter command
+var macfilterCmd = &cobra.Command{
+	Use:   "macfilter",
+	Short: "manages the wireless MAC filter",
+	Long: `macfilter shows and changes the router's wireless MAC filtering mode and adds or
+deletes the entries of its MAC filter list. Depending on the mode, the nodes on the
+list are either kept from connecting to the wireless networks (deny-list) or are the
+only ones allowed to connect (allow-list).
+
+MAC addresses may be given with colons, dashes or dots as separators or as 12 bare
+hex digits.`,
+	PersistentPreRun: newClient,
+}
+
+func init() {
+	rootCmd.AddCommand(macfilterCmd)
+	addRouterFlags(macfilterCmd)
+}
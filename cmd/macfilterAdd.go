@@ -0,0 +1,52 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// macfilterAddCmd represents the macfilter add command
+var macfilterAddCmd = &cobra.Command{
+	Use:   "add MAC [DESCRIPTION]",
+	Short: "adds a wireless MAC filter entry",
+	Long: `add adds an enabled entry for the given MAC address to the router's wireless MAC
+filter. Any further arguments are joined to form the entry's description.
+
+Examples:
+  tplink macfilter add 12:34:56:aa:bb:cc noisy laptop
+  tplink macfilter add 123456aabbcc`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := client.AddMACFilterEntryContext(ctx, args[0], strings.Join(args[1:], " ")); err != nil {
+			fatalf("got error adding MAC filter entry: %v", err)
+		}
+		fmt.Printf("added MAC filter entry for %s!\n", args[0])
+	},
+}
+
+func init() {
+	macfilterCmd.AddCommand(macfilterAddCmd)
+}
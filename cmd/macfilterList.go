@@ -0,0 +1,50 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// macfilterListCmd represents the macfilter list command
+var macfilterListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "displays the wireless MAC filter entries",
+	Long: `list queries the wifi router to get the entries of its wireless MAC filter and prints
+out the ID, MAC address, state, and description of each one.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		entries, err := client.ListMACFilterEntriesContext(ctx)
+		if err != nil {
+			fatalf("got error retrieving MAC filter entries (want a slice of *archerc9v1.MACFilterEntry): %v", err)
+		}
+		fmt.Printf("%-5s%-20s%-9s%s\n", "ID", "MAC_ADDRESS", "STATE", "DESCRIPTION")
+		for _, e := range entries {
+			fmt.Printf("%-5d%-20s%-9s%s\n", e.ID, e.MacAddress, onOff(e.Enabled), e.Description)
+		}
+	},
+}
+
+func init() {
+	macfilterCmd.AddCommand(macfilterListCmd)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+// macfilterModeCmd represents the macfilter mode command
+var macfilterModeCmd = &cobra.Command{
+	Use:   "mode [disabled | deny-list | allow-list]",
+	Short: "displays or changes the wireless MAC filtering mode",
+	Long: `mode prints out the router's wireless MAC filtering mode or, when given a mode,
+changes it. Beware that switching to allow-list disconnects every wireless node
+without an enabled entry, possibly including this one.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			mode, err := client.GetMACFilterModeContext(ctx)
+			if err != nil {
+				fatalf("got error retrieving MAC filter mode (want a MAC filter mode): %v", err)
+			}
+			fmt.Println(mode)
+			return
+		}
+
+		mode, err := archerc9v1.ParseMACFilterMode(args[0])
+		if err != nil {
+			fatalf("%v", err)
+		}
+		if err = client.SetMACFilterModeContext(ctx, mode); err != nil {
+			fatalf("got error setting MAC filter mode: %v", err)
+		}
+		fmt.Printf("MAC filter mode set to %s!\n", mode)
+	},
+}
+
+func init() {
+	macfilterCmd.AddCommand(macfilterModeCmd)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	parentalSchedule, parentalDescription string
+	parentalWebsites                      []string
+	parentalDisabled                      bool
+)
+
+// parentalAddCmd represents the parental add command
+var parentalAddCmd = &cobra.Command{
+	Use:   "add MAC",
+	Short: "adds a parental control rule",
+	Long: `add creates a parental control rule that lets the node with the given MAC address
+reach the Internet only during the schedule. The schedule is a comma separated list of
+days or day ranges (mon, tue, ..., sun or everyday) followed by a time window. If
+websites are given, the node may only visit those (at most 8).
+
+Examples:
+  tplink parental add 12-34-56-AA-BB-CC --schedule "mon-fri 07:00-21:00"
+  tplink parental add 12:34:56:aa:bb:cc --schedule "sat,sun 09:00-22:30" --website example.com --website school.org`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		schedule, err := archerc9v1.ParseSchedule(parentalSchedule)
+		if err != nil {
+			fatalf("got invalid --schedule: %v", err)
+		}
+		p := &archerc9v1.ParentalControl{
+			MacAddress:  args[0],
+			Description: parentalDescription,
+			Websites:    parentalWebsites,
+			Schedule:    *schedule,
+			Enabled:     !parentalDisabled,
+		}
+		if err = client.AddParentalControlContext(ctx, p); err != nil {
+			fatalf("got error adding parental control: %v", err)
+		}
+		fmt.Printf("added parental control for %s (%s)!\n", args[0], schedule)
+	},
+}
+
+func init() {
+	parentalCmd.AddCommand(parentalAddCmd)
+	parentalAddCmd.Flags().StringVar(&parentalSchedule, "schedule", "", `days and time window of Internet access, e.g. "mon-fri 07:00-21:00" (required)`)
+	parentalAddCmd.MarkFlagRequired("schedule")
+	parentalAddCmd.Flags().StringSliceVar(&parentalWebsites, "website", nil, "website the node may visit (repeatable, default any website)")
+	parentalAddCmd.Flags().StringVar(&parentalDescription, "description", "", "description of the rule")
+	parentalAddCmd.Flags().BoolVar(&parentalDisabled, "disabled", false, "add the rule in the disabled state")
+}
@@ -0,0 +1,59 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// parentalListCmd represents the parental list command
+var parentalListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "displays the parental control rules",
+	Long: `list queries the wifi router to get its parental control rules and prints out the
+MAC address, schedule, state, allowed websites, and description of each rule.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		controls, err := client.ListParentalControlsContext(ctx)
+		if err != nil {
+			fatalf("got error retrieving parental controls (want a []*archerc9v1.ParentalControl): %v", err)
+		}
+		if len(controls) == 0 {
+			fmt.Println("No parental control rules found")
+		}
+		fmt.Printf("%-22s%-30s%-9s%-30s%s\n", "MAC_ADDRESS", "SCHEDULE", "STATE", "WEBSITES", "DESCRIPTION")
+		for _, p := range controls {
+			websites := "any"
+			if len(p.Websites) > 0 {
+				websites = strings.Join(p.Websites, ",")
+			}
+			fmt.Printf("%-22s%-30s%-9s%-30s%s\n",
+				p.MacAddress, p.Schedule.String(), onOff(p.Enabled), websites, p.Description)
+		}
+	},
+}
+
+func init() {
+	parentalCmd.AddCommand(parentalListCmd)
+}
@@ -0,0 +1,50 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// portforwardCmd represents the portforward command
+var portforwardCmd = &cobra.Command{
+	Use:              "portforward",
+	Short:            "manages port forwarding (virtual server) rules",
+	Long:             `portforward lists, adds, deletes, enables and disables the router's port forwarding rules.`,
+	PersistentPreRun: newClient,
+}
+
+// parseRuleID parses the ID of a rule as printed by the list commands.
+func parseRuleID(arg string) int {
+	id, err := strconv.Atoi(arg)
+	if err != nil || id < 0 {
+		fatalf("got rule ID %q (want the non-negative ID shown by the list command)", arg)
+	}
+	return id
+}
+
+func init() {
+	rootCmd.AddCommand(portforwardCmd)
+	addRouterFlags(portforwardCmd)
+}
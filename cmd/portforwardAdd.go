@@ -0,0 +1,81 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	portforwardPorts, portforwardIP, portforwardProtocol string
+	portforwardInternalPort                              int
+	portforwardDisabled                                  bool
+)
+
+// portforwardAddCmd represents the portforward add command
+var portforwardAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "adds a port forwarding rule",
+	Long: `add creates a port forwarding rule that forwards traffic arriving at the router's
+service port(s) to a node on the LAN. The rule is rejected if its service ports overlap
+with those of an existing rule for the same protocol.
+
+Examples:
+  tplink portforward add --port 8080 --ip 192.168.0.20 --internal-port 80 --protocol tcp
+  tplink portforward add --port 27015-27030 --ip 192.168.0.21 --protocol udp`,
+	Run: func(cmd *cobra.Command, args []string) {
+		start, end, err := archerc9v1.ParsePortRange(portforwardPorts)
+		if err != nil {
+			fatalf("got invalid --port: %v", err)
+		}
+		protocol, err := archerc9v1.ParseProtocol(portforwardProtocol)
+		if err != nil {
+			fatalf("got invalid --protocol: %v", err)
+		}
+		pf := &archerc9v1.PortForward{
+			ExternalPortStart: start,
+			ExternalPortEnd:   end,
+			InternalIP:        portforwardIP,
+			InternalPort:      portforwardInternalPort,
+			Protocol:          protocol,
+			Enabled:           !portforwardDisabled,
+		}
+		if err = client.AddPortForwardContext(ctx, pf); err != nil {
+			fatalf("got error adding port forwarding rule: %v", err)
+		}
+		fmt.Printf("forwarding %s port(s) %s to %s!\n", protocol, portforwardPorts, portforwardIP)
+	},
+}
+
+func init() {
+	portforwardCmd.AddCommand(portforwardAddCmd)
+	portforwardAddCmd.Flags().StringVar(&portforwardPorts, "port", "", "service port or port range, e.g. 80 or 8000-8010 (required)")
+	portforwardAddCmd.MarkFlagRequired("port")
+	portforwardAddCmd.Flags().StringVar(&portforwardIP, "ip", "", "IP address of the node to forward to (required)")
+	portforwardAddCmd.MarkFlagRequired("ip")
+	portforwardAddCmd.Flags().IntVar(&portforwardInternalPort, "internal-port", 0, "port on the node to forward a single service port to (default same as service port)")
+	portforwardAddCmd.Flags().StringVar(&portforwardProtocol, "protocol", "all", "protocol to forward (tcp, udp or all)")
+	portforwardAddCmd.Flags().BoolVar(&portforwardDisabled, "disabled", false, "add the rule in the disabled state")
+}
@@ -0,0 +1,47 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// portforwardDisableCmd represents the portforward disable command
+var portforwardDisableCmd = &cobra.Command{
+	Use:   "disable ID",
+	Short: "disables a port forwarding rule",
+	Long:  `disable turns off the port forwarding rule with the given ID, as shown by "tplink portforward list".`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := parseRuleID(args[0])
+		if err := client.DisablePortForwardContext(ctx, id); err != nil {
+			fatalf("got error trying to disable port forwarding rule %d: %v", id, err)
+		}
+		fmt.Printf("disabled port forwarding rule %d!\n", id)
+	},
+}
+
+func init() {
+	portforwardCmd.AddCommand(portforwardDisableCmd)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// portforwardListCmd represents the portforward list command
+var portforwardListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "displays the port forwarding rules",
+	Long: `list queries the wifi router to get its port forwarding rules and prints out the ID,
+service ports, internal IP address, internal port, protocol, and status of each rule.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		rules, err := client.ListPortForwardsContext(ctx)
+		if err != nil {
+			fatalf("got error retrieving port forwarding rules (want a []*archerc9v1.PortForward): %v", err)
+		}
+		if len(rules) == 0 {
+			fmt.Println("No port forwarding rules found")
+		}
+		fmt.Printf("%-5s%-14s%-15s%-15s%-10s%-10s\n",
+			"ID", "SERVICE_PORT", "INTERNAL_IP", "INTERNAL_PORT", "PROTOCOL", "STATUS")
+		for _, r := range rules {
+			servicePort := strconv.Itoa(r.ExternalPortStart)
+			if r.ExternalPortEnd != r.ExternalPortStart {
+				servicePort += "-" + strconv.Itoa(r.ExternalPortEnd)
+			}
+			internalPort := "same"
+			if r.InternalPort != 0 {
+				internalPort = strconv.Itoa(r.InternalPort)
+			}
+			status := "disabled"
+			if r.Enabled {
+				status = "enabled"
+			}
+			fmt.Printf("%-5d%-14s%-15s%-15s%-10s%-10s\n",
+				r.ID, servicePort, r.InternalIP, internalPort, r.Protocol, status)
+		}
+	},
+}
+
+func init() {
+	portforwardCmd.AddCommand(portforwardListCmd)
+}
@@ -23,37 +23,43 @@ package cmd
 
 import (
 	"fmt"
-	"github.com/aculclasure/tplink/archerc9v1"
 	"github.com/spf13/cobra"
-	"log"
+	"time"
+)
+
+var (
+	rebootWait        bool
+	rebootWaitTimeout time.Duration
 )
 
 // rebootCmd represents the reboot command
 var rebootCmd = &cobra.Command{
 	Use:   "reboot",
 	Short: "reboots the router",
-	Long:  `reboot restarts the router!`,
-	PreRun: func(cmd *cobra.Command, args []string) {
-		var err error
-		client, err = archerc9v1.New(userName, password, url, nil, nil)
-		if err != nil {
-			log.Fatalf("got error trying to create new tplinkac9v1.Client: %s", err)
-		}
-	},
+	Long: `reboot restarts the router! With --wait, it also waits for the router to go down and
+come back up, i.e. to accept the login and list its connections again, and reports
+how long the router was down.`,
+	PersistentPreRun: newClient,
 	Run: func(cmd *cobra.Command, args []string) {
-		if err := client.Reboot(); err != nil {
-			log.Fatalf("got error rebooting the router (want a response that the router rebooted successfuly): %v", err)
+		if !rebootWait {
+			if err := client.RebootContext(ctx); err != nil {
+				fatalf("got error rebooting the router (want a response that the router rebooted successfuly): %v", err)
+			}
+			fmt.Println("router rebooted!")
+			return
+		}
+
+		downtime, err := client.RebootAndWait(ctx, rebootWaitTimeout)
+		if err != nil {
+			fatalf("got error rebooting the router and waiting for it to come back up: %v", err)
 		}
-		fmt.Println("router rebooted!")
+		fmt.Printf("router rebooted and back up after %s of downtime!\n", downtime.Round(time.Second))
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(rebootCmd)
-	rebootCmd.Flags().StringVar(&url, "url", "http://192.168.168.1", "router URL (required)")
-	rebootCmd.MarkFlagRequired("url")
-	rebootCmd.Flags().StringVarP(&userName, "user", "U", "admin", "router admin user name (required)")
-	rebootCmd.MarkFlagRequired("user")
-	rebootCmd.Flags().StringVarP(&password, "password", "P", "admin", "router admin password (required)")
-	rebootCmd.MarkFlagRequired("password")
+	addRouterFlags(rebootCmd)
+	rebootCmd.Flags().BoolVar(&rebootWait, "wait", false, "wait for the router to come back up")
+	rebootCmd.Flags().DurationVar(&rebootWaitTimeout, "wait-timeout", 3*time.Minute, "how long to wait for the router to come back up with --wait")
 }
@@ -0,0 +1,122 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"time"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scheduleCritical    []string
+	scheduleThreshold   int64
+	scheduleWaitTimeout time.Duration
+)
+
+// rebootScheduleCmd represents the reboot schedule command
+var rebootScheduleCmd = &cobra.Command{
+	Use:   "schedule <cron expression>",
+	Short: "reboots the router on a schedule",
+	Long: `schedule keeps running and reboots the router whenever the cron expression fires,
+waiting for it to come back up each time. The expression has the five standard fields
+(minute, hour, day of month, month, day of week) or is a descriptor such as @daily.
+
+If MAC addresses are given with --critical, a reboot is skipped while any of those
+nodes transfers at least --active-threshold bytes per second, according to the
+router's traffic statistics, which are turned on at the start if they are off. Each
+attempt is logged to standard error.
+
+The show and set subcommands manage the router's own built-in reboot schedule
+instead, if its firmware has one.
+
+Examples:
+  tplink reboot schedule "30 4 * * *"
+  tplink reboot schedule @daily --critical 12-34-56-AA-BB-CC --critical 1A-1A-1A-AA-AA-AA`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{defaultLogLevelAnnotation: "info"},
+	Run: func(cmd *cobra.Command, args []string) {
+		critical := make(map[string]bool)
+		for _, mac := range scheduleCritical {
+			normalized, err := archerc9v1.NormalizeMAC(mac)
+			if err != nil {
+				fatalf("got invalid critical MAC address: %v", err)
+			}
+			critical[normalized] = true
+		}
+		if len(critical) > 0 {
+			// Turning on the router's traffic statistics zeroes their counters, so
+			// turn them on now rather than in the first check of critical nodes.
+			if _, err := client.GetTrafficStatisticsContext(ctx); err != nil {
+				fatalf("got error turning on traffic statistics to check critical nodes: %v", err)
+			}
+		}
+
+		c := cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger)))
+		id, err := c.AddFunc(args[0], func() { scheduledReboot(critical) })
+		if err != nil {
+			fatalf("got invalid cron expression %q: %v", args[0], err)
+		}
+		c.Start()
+		logger.Info("reboot scheduled", "schedule", args[0], "next", c.Entry(id).Next.Format(time.RFC1123))
+		<-ctx.Done()
+		<-c.Stop().Done()
+		logger.Info("stopped scheduled reboots", "reason", ctx.Err())
+	},
+}
+
+// scheduledReboot reboots the router and waits for it to come back up, unless
+// one of the critical nodes, given by their normalized MAC addresses, is active.
+func scheduledReboot(critical map[string]bool) {
+	if len(critical) > 0 {
+		stats, err := client.GetTrafficStatisticsContext(ctx)
+		if err != nil {
+			logger.Error("skipping scheduled reboot, got error checking traffic of critical nodes", "error", err)
+			return
+		}
+		for _, s := range stats {
+			mac, err := archerc9v1.NormalizeMAC(s.MacAddress)
+			if err == nil && critical[mac] && s.BytesPerSecond >= scheduleThreshold {
+				logger.Info("skipping scheduled reboot, critical node is active", "mac", mac, "ip", s.IPAddress,
+					"bytes_per_second", s.BytesPerSecond)
+				return
+			}
+		}
+	}
+
+	logger.Info("rebooting the router as scheduled")
+	downtime, err := client.RebootAndWait(ctx, scheduleWaitTimeout)
+	if err != nil {
+		logger.Error("got error in scheduled reboot", "error", err)
+		return
+	}
+	logger.Info("scheduled reboot done", "downtime", downtime.Round(time.Second))
+}
+
+func init() {
+	rebootCmd.AddCommand(rebootScheduleCmd)
+	rebootScheduleCmd.Flags().StringSliceVar(&scheduleCritical, "critical", nil, "MAC address of a node whose activity prevents the reboot (repeatable)")
+	rebootScheduleCmd.Flags().Int64Var(&scheduleThreshold, "active-threshold", 1024, "bytes per second above which a critical node counts as active")
+	rebootScheduleCmd.Flags().DurationVar(&scheduleWaitTimeout, "wait-timeout", 3*time.Minute, "how long to wait for the router to come back up after each reboot")
+}
@@ -0,0 +1,84 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rebootScheduleDays, rebootScheduleTime string
+	rebootScheduleDisable                  bool
+)
+
+// rebootScheduleSetCmd represents the reboot schedule set command
+var rebootScheduleSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "changes the router's built-in reboot schedule",
+	Long: `set makes the router reboot by itself at --time on --days, or stops it from doing so
+with --disable. It fails if the router's firmware has no built-in reboot schedule.
+
+Examples:
+  tplink reboot schedule set --days everyday --time 04:00
+  tplink reboot schedule set --days sat,sun --time 05:30
+  tplink reboot schedule set --disable`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		s := &archerc9v1.RebootSchedule{}
+		if !rebootScheduleDisable {
+			days, err := archerc9v1.ParseWeekdays(rebootScheduleDays)
+			if err != nil {
+				fatalf("got invalid --days: %v", err)
+			}
+			t, err := time.Parse("15:04", rebootScheduleTime)
+			if err != nil {
+				fatalf("got invalid --time %q (want HH:MM)", rebootScheduleTime)
+			}
+			s = &archerc9v1.RebootSchedule{
+				Enabled: true,
+				Days:    days,
+				Time:    time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute,
+			}
+		}
+
+		err := client.SetRebootScheduleContext(ctx, s)
+		if errors.Is(err, archerc9v1.ErrRebootScheduleUnsupported) {
+			fatalf("the router's firmware has no built-in reboot schedule, use \"tplink reboot schedule <cron expression>\" instead")
+		}
+		if err != nil {
+			fatalf("got error changing reboot schedule: %v", err)
+		}
+		fmt.Printf("reboot schedule set to %s!\n", s)
+	},
+}
+
+func init() {
+	rebootScheduleCmd.AddCommand(rebootScheduleSetCmd)
+	rebootScheduleSetCmd.Flags().StringVar(&rebootScheduleDays, "days", "everyday", "days to reboot on, e.g. mon-fri or sat,sun")
+	rebootScheduleSetCmd.Flags().StringVar(&rebootScheduleTime, "time", "04:00", "time of day to reboot at (HH:MM)")
+	rebootScheduleSetCmd.Flags().BoolVar(&rebootScheduleDisable, "disable", false, "stop rebooting on a schedule")
+}
@@ -0,0 +1,53 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// rebootScheduleShowCmd represents the reboot schedule show command
+var rebootScheduleShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "shows the router's built-in reboot schedule",
+	Long: `show prints the reboot schedule the router runs by itself, e.g. "mon-fri 04:00", or
+"disabled". It fails if the router's firmware has no built-in reboot schedule.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		s, err := client.GetRebootScheduleContext(ctx)
+		if errors.Is(err, archerc9v1.ErrRebootScheduleUnsupported) {
+			fatalf("the router's firmware has no built-in reboot schedule, use \"tplink reboot schedule <cron expression>\" instead")
+		}
+		if err != nil {
+			fatalf("got error retrieving reboot schedule (want a *archerc9v1.RebootSchedule): %v", err)
+		}
+		fmt.Println(s)
+	},
+}
+
+func init() {
+	rebootScheduleCmd.AddCommand(rebootScheduleShowCmd)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reservationIP       string
+	reservationDisabled bool
+)
+
+// reservationsAddCmd represents the reservations add command
+var reservationsAddCmd = &cobra.Command{
+	Use:   "add (MAC [IP] | ROW | -)",
+	Short: "adds a static DHCP address reservation",
+	Long: `add reserves an IP address for the node with the given MAC address. The IP address
+must lie inside the DHCP server's address pool and neither the MAC address nor the
+IP address may already have a reservation.
+
+Instead of a MAC address and IP address, add also accepts a row of the output of
+"tplink list wiredClients" or "tplink list wirelessClients", either as arguments or
+read from standard input when the only argument is "-". The node's current IP
+address is then reserved unless the --ip flag is given.
+
+Examples:
+  tplink reservations add 12-34-56-AA-BB-CC 192.168.0.150
+  tplink reservations add 10.100.100.100     12-34-56-AA-BB-CC     FakeHost
+  tplink list wiredClients | grep printer | tplink reservations add -`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		fields := strings.Fields(strings.Join(args, " "))
+		if len(args) == 1 && args[0] == "-" {
+			line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			if err != nil && len(line) == 0 {
+				fatalf("got error reading client row from standard input: %v", err)
+			}
+			fields = strings.Fields(line)
+		}
+
+		mac, ip := parseClientRow(fields)
+		if cmd.Flags().Changed("ip") {
+			ip = reservationIP
+		}
+		if mac == "" || ip == "" {
+			fatalf("got %q (want a MAC address and an IP address)", strings.Join(fields, " "))
+		}
+
+		r := &archerc9v1.Reservation{MacAddress: mac, IPAddress: ip, Enabled: !reservationDisabled}
+		if err := client.AddReservationContext(ctx, r); err != nil {
+			fatalf("got error adding DHCP reservation: %v", err)
+		}
+		fmt.Printf("reserved %s for %s!\n", ip, mac)
+	},
+}
+
+// parseClientRow returns the first MAC address and the first IP address found
+// in fields, which may be a row of the wiredClients/wirelessClients output.
+func parseClientRow(fields []string) (mac, ip string) {
+	for _, f := range fields {
+		if m, err := archerc9v1.NormalizeMAC(f); err == nil && mac == "" {
+			mac = m
+		} else if net.ParseIP(f) != nil && ip == "" {
+			ip = f
+		}
+	}
+	return mac, ip
+}
+
+func init() {
+	reservationsCmd.AddCommand(reservationsAddCmd)
+	reservationsAddCmd.Flags().StringVar(&reservationIP, "ip", "", "IP address to reserve (overrides the IP address in a client row)")
+	reservationsAddCmd.Flags().BoolVar(&reservationDisabled, "disabled", false, "add the reservation in the disabled state")
+}
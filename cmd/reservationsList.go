@@ -0,0 +1,57 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// reservationsListCmd represents the reservations list command
+var reservationsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "displays the static DHCP address reservations",
+	Long: `list queries the wifi router to get its static DHCP address reservations and prints
+out the reserved IP address, MAC address, and status for each reservation.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		reservations, err := client.ListReservationsContext(ctx)
+		if err != nil {
+			fatalf("got error retrieving DHCP reservations (want a []*archerc9v1.Reservation): %v", err)
+		}
+		if len(reservations) == 0 {
+			fmt.Println("No DHCP reservations found")
+		}
+		fmt.Printf("%-15s%-22s%-10s\n", "IP_ADDRESS", "MAC_ADDRESS", "STATUS")
+		for _, r := range reservations {
+			status := "disabled"
+			if r.Enabled {
+				status = "enabled"
+			}
+			fmt.Printf("%-15s%-22s%-10s\n", r.IPAddress, r.MacAddress, status)
+		}
+	},
+}
+
+func init() {
+	reservationsCmd.AddCommand(reservationsListCmd)
+}
@@ -23,30 +23,209 @@ THE SOFTWARE.
 package cmd
 
 import (
+	"bufio"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/aculclasure/tplink/archerc9v1"
 	"github.com/spf13/cobra"
+	"log"
+	"net"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 )
 
 var (
 	url, userName, password string
 	client                  *archerc9v1.Client
-	rootCmd                 = &cobra.Command{
+	timeout                 time.Duration
+	// ctx is the context of the requests to the router. It is done when the
+	// user interrupts the command or when the --timeout elapses.
+	ctx         context.Context
+	stopTimeout context.CancelFunc
+	// logger is the logger of client, which long running commands also use to
+	// log their progress. It is set up from the --log-level and --log-format
+	// flags by newClient.
+	logger                      = newLogger(archerc9v1.LevelWarn, archerc9v1.TextFormat)
+	logLevelName, logFormatName string
+	rootCmd                     = &cobra.Command{
 		Use:   "tplink",
 		Short: "provides a minimal admin interface to a TP Link wifi router",
 		Long: `
-tplink is a CLI app that provides a very minimal admin interface to a TP Link
-Archer C9 V1 home wifi router. This app allows a user to retrieve information
-about connected clients on the router and to also reboot the router.`,
+tplink is a CLI app that provides a minimal admin interface to a TP Link Archer C9
+V1 home wifi router. It shows the router's status, connected clients, DHCP leases,
+traffic statistics and system log, changes its WAN, wifi, guest network, DHCP,
+MAC filter, parental control and port forwarding settings, blocks nodes, backs up
+and restores its configuration, upgrades its firmware, and reboots it, on demand,
+on a schedule or when a watchdog finds the Internet connection lost.
+
+Commands that talk to the router take its address and admin credentials from
+--url, --user (-U) and --password (-P). Every command accepts --timeout, which
+bounds how long it may take, and --log-level and --log-format, which choose which
+messages are logged to standard error and whether as text or JSON. Warnings are
+logged by default, and progress too for long running commands.
+
+When a command fails, tplink exits with a code that tells why:
+  1    any other error
+  3    the router rejected the user name or password
+  4    another administrator is logged in to the router
+  5    the router is too busy to handle the request
+  6    the router sent a response tplink could not make sense of
+  7    the router could not be reached
+  8    the --timeout elapsed
+  130  the command was interrupted`,
 	}
 )
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.ExecuteContext(interruptContext())
+	if stopTimeout != nil {
+		stopTimeout()
+	}
+	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 }
+
+func init() {
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "maximum time the command may take, e.g. 30s (0 for no limit)")
+	rootCmd.PersistentFlags().StringVar(&logLevelName, "log-level", "", "least severe level of the messages to log: debug, info, warn or error (default warn, info for long running commands)")
+	rootCmd.PersistentFlags().StringVar(&logFormatName, "log-format", "text", "format of the messages logged to standard error: text or json")
+}
+
+// defaultLogLevelAnnotation is the key of the annotation with which a command
+// can log more than warnings by default, e.g. a long running command that logs
+// its progress at the info level.
+const defaultLogLevelAnnotation = "defaultLogLevel"
+
+// newLogger returns a logger that logs messages of the given level or more
+// severe ones to standard error in the given format.
+func newLogger(level archerc9v1.Level, format archerc9v1.Format) archerc9v1.Logger {
+	flags := log.LstdFlags
+	if format == archerc9v1.JSONFormat {
+		flags = 0
+	}
+	return archerc9v1.NewStdLogger(log.New(os.Stderr, "", flags), level, format)
+}
+
+// interruptContext returns a context that is canceled when the process receives
+// an interrupt (Ctrl-C) or a termination signal, so that commands can stop their
+// requests to the router. A second signal exits right away.
+func interruptContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		cancel()
+		<-signals
+		os.Exit(exitInterrupted)
+	}()
+	return ctx
+}
+
+// addRouterFlags adds the flags needed to connect to the router as persistent
+// flags of cmd so that they are available to all of its subcommands.
+func addRouterFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&url, "url", "http://192.168.168.1", "router URL (required)")
+	cmd.MarkFlagRequired("url")
+	cmd.PersistentFlags().StringVarP(&userName, "user", "U", "admin", "router admin user name (required)")
+	cmd.MarkFlagRequired("user")
+	cmd.PersistentFlags().StringVarP(&password, "password", "P", "admin", "router admin password (required)")
+	cmd.MarkFlagRequired("password")
+}
+
+// newClient creates the client used to talk to the router from the router
+// flags. It is meant to be used as the PersistentPreRun of the commands that
+// call addRouterFlags.
+func newClient(cmd *cobra.Command, args []string) {
+	ctx = cmd.Context()
+	if timeout > 0 {
+		ctx, stopTimeout = context.WithTimeout(ctx, timeout)
+	}
+
+	name := logLevelName
+	if name == "" {
+		name = cmd.Annotations[defaultLogLevelAnnotation]
+	}
+	level := archerc9v1.LevelWarn
+	var err error
+	if name != "" {
+		if level, err = archerc9v1.ParseLevel(name); err != nil {
+			fatalf("got invalid --log-level: %v", err)
+		}
+	}
+	format, err := archerc9v1.ParseFormat(logFormatName)
+	if err != nil {
+		fatalf("got invalid --log-format: %v", err)
+	}
+	logger = newLogger(level, format)
+
+	client, err = archerc9v1.NewClient(url, archerc9v1.WithCredentials(userName, password), archerc9v1.WithLogger(logger))
+	if err != nil {
+		fatalf("got error trying to create new tplinkac9v1.Client: %s", err)
+	}
+}
+
+// The exit codes of tplink, which tell scripts why a command failed.
+const (
+	exitError              = 1
+	exitUnauthorized       = 3
+	exitSessionLocked      = 4
+	exitRouterBusy         = 5
+	exitUnexpectedResponse = 6
+	exitUnreachable        = 7
+	exitTimeout            = 8
+	exitInterrupted        = 130
+)
+
+// exitCode returns the exit code that tells why err happened.
+func exitCode(err error) int {
+	var netErr net.Error
+	switch {
+	case errors.Is(err, archerc9v1.ErrUnauthorized):
+		return exitUnauthorized
+	case errors.Is(err, archerc9v1.ErrSessionLocked):
+		return exitSessionLocked
+	case errors.Is(err, archerc9v1.ErrRouterBusy):
+		return exitRouterBusy
+	case errors.Is(err, archerc9v1.ErrUnexpectedResponse):
+		return exitUnexpectedResponse
+	case errors.Is(err, context.Canceled):
+		return exitInterrupted
+	case errors.Is(err, context.DeadlineExceeded):
+		return exitTimeout
+	case errors.As(err, &netErr):
+		return exitUnreachable
+	}
+	return exitError
+}
+
+// fatalf logs the message like log.Fatalf and exits with the exit code of the
+// first error in args, or with exitError if there is none.
+func fatalf(format string, args ...interface{}) {
+	log.Printf(format, args...)
+	code := exitError
+	for _, arg := range args {
+		if err, ok := arg.(error); ok {
+			code = exitCode(err)
+			break
+		}
+	}
+	os.Exit(code)
+}
+
+// confirm asks the user the yes/no question prompt on standard error and reports
+// whether the answer read from standard input is yes.
+func confirm(prompt string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", prompt)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsSortBy string
+	statsTop    int
+)
+
+// statsCmd represents the stats command
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "displays per client traffic statistics",
+	Long: `stats queries the wifi router to get its traffic statistics and prints out the IP
+address, MAC address, host name, total packets, total bytes, and current rates of each
+client. The statistics are enabled on the router first if needed, in which case all
+counters start at zero.
+
+Use --sort to order the clients by total bytes (the default), total packets or current
+bytes per second (rate), and --top to only show the heaviest clients.
+
+Example:
+  tplink stats --sort rate --top 5`,
+	PersistentPreRun: newClient,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if statsSortBy != "bytes" && statsSortBy != "packets" && statsSortBy != "rate" {
+			fatalf("got --sort %q (want bytes, packets or rate)", statsSortBy)
+		}
+		if statsTop < 0 {
+			fatalf("got --top %d (want 0 for all clients or more)", statsTop)
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		stats, err := client.GetTrafficStatisticsContext(ctx)
+		if err != nil {
+			fatalf("got error retrieving traffic statistics (want a []*archerc9v1.TrafficStatistics): %v", err)
+		}
+		names := connectionNames()
+
+		sort.SliceStable(stats, func(i, j int) bool {
+			switch statsSortBy {
+			case "packets":
+				return stats[i].TotalPackets > stats[j].TotalPackets
+			case "rate":
+				return stats[i].BytesPerSecond > stats[j].BytesPerSecond
+			}
+			return stats[i].TotalBytes > stats[j].TotalBytes
+		})
+		if statsTop > 0 && len(stats) > statsTop {
+			stats = stats[:statsTop]
+		}
+
+		if len(stats) == 0 {
+			fmt.Println("No traffic statistics found")
+		}
+		fmt.Printf("%-15s%-20s%-20s%-14s%-16s%-10s%-12s\n",
+			"IP_ADDRESS", "MAC_ADDRESS", "HOST_NAME", "PACKETS", "BYTES", "PKTS/S", "BYTES/S")
+		for _, s := range stats {
+			mac, _ := archerc9v1.NormalizeMAC(s.MacAddress)
+			fmt.Printf("%-15s%-20s%-20s%-14d%-16d%-10d%-12d\n",
+				s.IPAddress, s.MacAddress, names[mac], s.TotalPackets, s.TotalBytes, s.PacketsPerSecond, s.BytesPerSecond)
+		}
+	},
+}
+
+// connectionNames returns the host names of the connected wired and wireless
+// clients keyed by their normalized MAC addresses.
+func connectionNames() map[string]string {
+	wired, err := client.GetWiredConnectionsContext(ctx)
+	if err != nil {
+		fatalf("got error retrieving wired connections (want a []*archerc9v1.Connection): %v", err)
+	}
+	wireless, err := client.GetWirelessConnectionsContext(ctx)
+	if err != nil {
+		fatalf("got error retrieving wireless connections (want a []*archerc9v1.Connection): %v", err)
+	}
+	names := make(map[string]string)
+	for _, c := range append(wired, wireless...) {
+		if mac, err := archerc9v1.NormalizeMAC(c.MacAddress); err == nil {
+			names[mac] = c.Name
+		}
+	}
+	return names
+}
+
+func init() {
+	rootCmd.AddCommand(statsCmd)
+	addRouterFlags(statsCmd)
+	statsCmd.Flags().StringVar(&statsSortBy, "sort", "bytes", "order clients by bytes, packets or rate, highest first")
+	statsCmd.Flags().IntVar(&statsTop, "top", 0, "only show the top N clients (0 for all)")
+}
@@ -0,0 +1,106 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var statusOutput string
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "displays the router's firmware, uptime, and interface status",
+	Long: `status queries the wifi router's status page and prints out its firmware and
+hardware versions, uptime, and the state of its LAN, WAN, and wireless interfaces.
+Use --output json for machine readable output.`,
+	PersistentPreRun: newClient,
+	PreRun: func(cmd *cobra.Command, args []string) {
+		if statusOutput != "table" && statusOutput != "json" {
+			fatalf("got --output %q (want table or json)", statusOutput)
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		s, err := client.GetStatusContext(ctx)
+		if err != nil {
+			fatalf("got error retrieving router status (want a *archerc9v1.Status): %v", err)
+		}
+		if statusOutput == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			if err = enc.Encode(s); err != nil {
+				fatalf("got error encoding router status as JSON: %v", err)
+			}
+			return
+		}
+		printStatus(s)
+	},
+}
+
+func printStatus(s *archerc9v1.Status) {
+	fmt.Printf("%-18s%s\n", "FIRMWARE", s.FirmwareVersion)
+	fmt.Printf("%-18s%s\n", "HARDWARE", s.HardwareVersion)
+	fmt.Printf("%-18s%s\n", "UPTIME", s.Uptime)
+	fmt.Println()
+	fmt.Printf("%-18s%s\n", "LAN_IP_ADDRESS", s.LAN.IPAddress)
+	fmt.Printf("%-18s%s\n", "LAN_SUBNET_MASK", s.LAN.SubnetMask)
+	fmt.Printf("%-18s%s\n", "LAN_MAC_ADDRESS", s.LAN.MacAddress)
+	fmt.Println()
+	fmt.Printf("%-18s%s\n", "WAN_TYPE", s.WAN.ConnectionType)
+	fmt.Printf("%-18s%s\n", "WAN_IP_ADDRESS", orNone(s.WAN.IPAddress))
+	fmt.Printf("%-18s%s\n", "WAN_SUBNET_MASK", orNone(s.WAN.SubnetMask))
+	fmt.Printf("%-18s%s\n", "WAN_GATEWAY", orNone(s.WAN.Gateway))
+	fmt.Printf("%-18s%s\n", "WAN_DNS_SERVERS", orNone(strings.Join(s.WAN.DNSServers, ", ")))
+	fmt.Printf("%-18s%s\n", "WAN_MAC_ADDRESS", s.WAN.MacAddress)
+	fmt.Println()
+	fmt.Printf("%-8s%-7s%-33s%-9s%-7s%-7s%-22s\n", "BAND", "RADIO", "SSID", "CHANNEL", "WIDTH", "MODE", "MAC_ADDRESS")
+	for _, w := range s.Wireless {
+		width := "auto"
+		if w.ChannelWidth != 0 {
+			width = strconv.Itoa(w.ChannelWidth)
+		}
+		fmt.Printf("%-8s%-7s%-33s%-9d%-7s%-7s%-22s\n",
+			w.Band, onOff(w.RadioEnabled), w.SSID, w.Channel, width, w.Mode, w.MacAddress)
+	}
+}
+
+// orNone returns s or "none" if s is empty.
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	addRouterFlags(statusCmd)
+	statusCmd.Flags().StringVarP(&statusOutput, "output", "o", "table", "output format (table or json)")
+}
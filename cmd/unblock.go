@@ -0,0 +1,75 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+// unblockCmd represents the unblock command
+var unblockCmd = &cobra.Command{
+	Use:   "unblock (MAC | IP | HOST_NAME)",
+	Short: "unblocks a node blocked with the block command",
+	Long: `unblock deletes the node with the given MAC address from the router's access control
+blacklist. Instead of a MAC address, the name of the blacklist entry or the IP address
+or host name of a connected node may be given.`,
+	Args:             cobra.ExactArgs(1),
+	PersistentPreRun: newClient,
+	Run: func(cmd *cobra.Command, args []string) {
+		mac, err := archerc9v1.NormalizeMAC(args[0])
+		if err != nil {
+			mac = findBlockedMAC(args[0])
+		}
+		if err = client.UnblockDeviceContext(ctx, mac); err != nil {
+			fatalf("got error unblocking %s: %v", mac, err)
+		}
+		fmt.Printf("unblocked %s!\n", mac)
+	},
+}
+
+// findBlockedMAC returns the MAC address of the blacklist entry named id or,
+// failing that, of the connected node with the IP address or host name id.
+func findBlockedMAC(id string) string {
+	blocked, err := client.ListBlockedDevicesContext(ctx)
+	if err != nil {
+		fatalf("got error retrieving blocked devices (want a []*archerc9v1.BlockedDevice): %v", err)
+	}
+	for _, d := range blocked {
+		if strings.EqualFold(d.Name, id) {
+			return d.MacAddress
+		}
+	}
+	conn, err := client.FindConnectionContext(ctx, id)
+	if err != nil {
+		fatalf("got error finding node to unblock: %v", err)
+	}
+	return conn.MacAddress
+}
+
+func init() {
+	rootCmd.AddCommand(unblockCmd)
+	addRouterFlags(unblockCmd)
+}
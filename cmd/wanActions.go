@@ -0,0 +1,107 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var wanActionWait time.Duration
+
+// wanRenewCmd represents the wan renew command
+var wanRenewCmd = &cobra.Command{
+	Use:   "renew",
+	Short: "renews the DHCP lease of the WAN address",
+	Long: `renew asks the ISP's DHCP server to renew the lease of the router's WAN address and
+waits for the WAN interface to lose its address and get one again, or to get a
+different address. A renewed lease usually keeps the same address, so the WAN
+interface still having an address after --wait also counts as success. It only
+works with a dynamic IP connection.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runWANAction(client.RenewWANContext, "renewed WAN lease")
+	},
+}
+
+// wanReleaseCmd represents the wan release command
+var wanReleaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "releases the DHCP lease of the WAN address",
+	Long: `release gives the router's WAN address back to the ISP's DHCP server and waits for
+the WAN interface to lose its address. It only works with a dynamic IP connection.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runWANAction(client.ReleaseWANContext, "released WAN lease")
+	},
+}
+
+// wanConnectCmd represents the wan connect command
+var wanConnectCmd = &cobra.Command{
+	Use:   "connect",
+	Short: "dials the PPPoE connection",
+	Long: `connect dials the router's PPPoE connection and waits for the WAN interface to lose
+its address and get one again, or to get a different address. A connection that is
+already up may keep its address, so the WAN interface still having an address after
+--wait also counts as success. It only works with a PPPoE connection.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runWANAction(client.ConnectPPPoEContext, "PPPoE connected")
+	},
+}
+
+// wanDisconnectCmd represents the wan disconnect command
+var wanDisconnectCmd = &cobra.Command{
+	Use:   "disconnect",
+	Short: "hangs up the PPPoE connection",
+	Long: `disconnect hangs up the router's PPPoE connection and waits for the WAN interface to
+lose its address. It only works with a PPPoE connection.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		runWANAction(client.DisconnectPPPoEContext, "PPPoE disconnected")
+	},
+}
+
+// runWANAction runs action, waiting up to the --wait flag for it to take
+// effect, and reports the resulting WAN address.
+func runWANAction(action func(context.Context, time.Duration) (*archerc9v1.WANStatus, error), done string) {
+	wan, err := action(ctx, wanActionWait)
+	if err != nil {
+		fatalf("got error in WAN action: %v", err)
+	}
+	if wan == nil {
+		fmt.Printf("%s (not waiting for the result)!\n", done)
+		return
+	}
+	fmt.Printf("%s! WAN IP address: %s\n", done, orNone(wan.IPAddress))
+}
+
+func init() {
+	for _, c := range []*cobra.Command{wanRenewCmd, wanReleaseCmd, wanConnectCmd, wanDisconnectCmd} {
+		wanCmd.AddCommand(c)
+		c.Flags().DurationVar(&wanActionWait, "wait", 30*time.Second, "how long to wait for the action to take effect (0 to not wait)")
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	wanMTU      int
+	wanCloneMAC string
+)
+
+// wanSetCmd represents the wan set command
+var wanSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "changes the WAN connection settings",
+	Long: `set switches the router's WAN interface to the given connection type or changes the
+settings of the current type. When the type stays the same, only the settings given as
+flags are changed. When switching types, the MTU is reset to the new type's default
+unless --mtu is given. Beware that the router drops its WAN connection while it applies
+the settings.`,
+}
+
+// setWANSettings applies the --mtu and --clone-mac flags of cmd to s, which
+// holds the config for connection type t, and saves s on the router.
+func setWANSettings(cmd *cobra.Command, s *archerc9v1.WANSettings, t archerc9v1.WANConnectionType) {
+	flags := cmd.Flags()
+	if s.Type != t {
+		s.MTU = 0
+	}
+	s.Type = t
+	if flags.Changed("mtu") {
+		s.MTU = wanMTU
+	}
+	if flags.Changed("clone-mac") {
+		s.MACClone = wanCloneMAC
+	}
+
+	if err := client.SetWANSettingsContext(ctx, s); err != nil {
+		fatalf("got error changing WAN settings: %v", err)
+	}
+	fmt.Printf("WAN connection set to %s!\n", t)
+}
+
+// getWANSettings returns the current WAN settings of the router.
+func getWANSettings() *archerc9v1.WANSettings {
+	s, err := client.GetWANSettingsContext(ctx)
+	if err != nil {
+		fatalf("got error retrieving current WAN settings (want a *archerc9v1.WANSettings): %v", err)
+	}
+	return s
+}
+
+func init() {
+	wanCmd.AddCommand(wanSetCmd)
+	wanSetCmd.PersistentFlags().IntVar(&wanMTU, "mtu", 0, "MTU of the WAN interface (0 for the connection type's default)")
+	wanSetCmd.PersistentFlags().StringVar(&wanCloneMAC, "clone-mac", "", "MAC address the WAN interface presents to the ISP (empty for its own)")
+}
@@ -0,0 +1,64 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	wanHostName   string
+	wanDynamicDNS []string
+)
+
+// wanSetDynamicCmd represents the wan set dynamic command
+var wanSetDynamicCmd = &cobra.Command{
+	Use:   "dynamic",
+	Short: "gets the WAN address from the ISP's DHCP server",
+	Long: `dynamic makes the router's WAN interface get its address from the ISP's DHCP server.
+The DNS servers handed out by the ISP are used unless --dns is given.
+
+Example:
+  tplink wan set dynamic --dns 1.1.1.1,8.8.8.8`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		s := getWANSettings()
+		if s.Type != archerc9v1.WANDynamicIP {
+			s.Dynamic = &archerc9v1.DynamicIPConfig{}
+		}
+		flags := cmd.Flags()
+		if flags.Changed("host-name") {
+			s.Dynamic.HostName = wanHostName
+		}
+		if flags.Changed("dns") {
+			s.Dynamic.DNSServers = wanDynamicDNS
+		}
+		setWANSettings(cmd, s, archerc9v1.WANDynamicIP)
+	},
+}
+
+func init() {
+	wanSetCmd.AddCommand(wanSetDynamicCmd)
+	wanSetDynamicCmd.Flags().StringVar(&wanHostName, "host-name", "", "host name the router sends to the ISP's DHCP server")
+	wanSetDynamicCmd.Flags().StringSliceVar(&wanDynamicDNS, "dns", nil, "up to two comma separated DNS servers to use instead of the ISP's (empty for the ISP's)")
+}
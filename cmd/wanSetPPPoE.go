@@ -0,0 +1,66 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var wanPPPoEUser, wanPPPoEPassword, wanPPPoEService string
+
+// wanSetPPPoECmd represents the wan set pppoe command
+var wanSetPPPoECmd = &cobra.Command{
+	Use:   "pppoe",
+	Short: "logs in to the ISP with PPPoE",
+	Long: `pppoe makes the router's WAN interface log in to the ISP with PPPoE. When switching
+from another connection type, --pppoe-user and --pppoe-password are required. Note that
+--user and --password are the credentials for the router itself.
+
+Example:
+  tplink wan set pppoe --pppoe-user customer@isp --pppoe-password secret`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		s := getWANSettings()
+		if s.Type != archerc9v1.WANPPPoE {
+			s.PPPoE = &archerc9v1.PPPoEConfig{}
+		}
+		flags := cmd.Flags()
+		if flags.Changed("pppoe-user") {
+			s.PPPoE.UserName = wanPPPoEUser
+		}
+		if flags.Changed("pppoe-password") {
+			s.PPPoE.Password = wanPPPoEPassword
+		}
+		if flags.Changed("service") {
+			s.PPPoE.ServiceName = wanPPPoEService
+		}
+		setWANSettings(cmd, s, archerc9v1.WANPPPoE)
+	},
+}
+
+func init() {
+	wanSetCmd.AddCommand(wanSetPPPoECmd)
+	wanSetPPPoECmd.Flags().StringVar(&wanPPPoEUser, "pppoe-user", "", "user name for the ISP's PPPoE login")
+	wanSetPPPoECmd.Flags().StringVar(&wanPPPoEPassword, "pppoe-password", "", "password for the ISP's PPPoE login")
+	wanSetPPPoECmd.Flags().StringVar(&wanPPPoEService, "service", "", "PPPoE service name, if the ISP requires one")
+}
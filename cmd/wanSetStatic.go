@@ -0,0 +1,72 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	wanIP, wanSubnetMask, wanGateway string
+	wanStaticDNS                     []string
+)
+
+// wanSetStaticCmd represents the wan set static command
+var wanSetStaticCmd = &cobra.Command{
+	Use:   "static",
+	Short: "uses a fixed WAN address assigned by the ISP",
+	Long: `static makes the router's WAN interface use the fixed address assigned by the ISP.
+When switching from another connection type, --ip, --mask, and --dns are required.
+
+Example:
+  tplink wan set static --ip 203.0.113.7 --mask 255.255.255.0 --gateway 203.0.113.1 --dns 198.51.100.53`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		s := getWANSettings()
+		if s.Type != archerc9v1.WANStaticIP {
+			s.Static = &archerc9v1.StaticIPConfig{}
+		}
+		flags := cmd.Flags()
+		if flags.Changed("ip") {
+			s.Static.IPAddress = wanIP
+		}
+		if flags.Changed("mask") {
+			s.Static.SubnetMask = wanSubnetMask
+		}
+		if flags.Changed("gateway") {
+			s.Static.Gateway = wanGateway
+		}
+		if flags.Changed("dns") {
+			s.Static.DNSServers = wanStaticDNS
+		}
+		setWANSettings(cmd, s, archerc9v1.WANStaticIP)
+	},
+}
+
+func init() {
+	wanSetCmd.AddCommand(wanSetStaticCmd)
+	wanSetStaticCmd.Flags().StringVar(&wanIP, "ip", "", "WAN IP address")
+	wanSetStaticCmd.Flags().StringVar(&wanSubnetMask, "mask", "", "WAN subnet mask")
+	wanSetStaticCmd.Flags().StringVar(&wanGateway, "gateway", "", "default gateway (empty to unset)")
+	wanSetStaticCmd.Flags().StringSliceVar(&wanStaticDNS, "dns", nil, "one or two comma separated DNS servers")
+}
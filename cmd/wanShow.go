@@ -0,0 +1,73 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var wanShowPassword bool
+
+// wanShowCmd represents the wan show command
+var wanShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "displays the WAN connection settings",
+	Long: `show queries the wifi router to get the settings of its WAN interface and prints out
+the connection type, the settings of that type, the MTU, and the cloned MAC address.
+The PPPoE password is masked unless --show-password is given.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		s, err := client.GetWANSettingsContext(ctx)
+		if err != nil {
+			fatalf("got error retrieving WAN settings (want a *archerc9v1.WANSettings): %v", err)
+		}
+		fmt.Printf("%-15s%s\n", "TYPE", s.Type)
+		switch s.Type {
+		case archerc9v1.WANDynamicIP:
+			fmt.Printf("%-15s%s\n", "HOST_NAME", orNone(s.Dynamic.HostName))
+			fmt.Printf("%-15s%s\n", "DNS_SERVERS", orNone(strings.Join(s.Dynamic.DNSServers, ", ")))
+		case archerc9v1.WANStaticIP:
+			fmt.Printf("%-15s%s\n", "IP_ADDRESS", s.Static.IPAddress)
+			fmt.Printf("%-15s%s\n", "SUBNET_MASK", s.Static.SubnetMask)
+			fmt.Printf("%-15s%s\n", "GATEWAY", orNone(s.Static.Gateway))
+			fmt.Printf("%-15s%s\n", "DNS_SERVERS", orNone(strings.Join(s.Static.DNSServers, ", ")))
+		case archerc9v1.WANPPPoE:
+			password := strings.Repeat("*", 8)
+			if wanShowPassword {
+				password = s.PPPoE.Password
+			}
+			fmt.Printf("%-15s%s\n", "USER_NAME", s.PPPoE.UserName)
+			fmt.Printf("%-15s%s\n", "PASSWORD", password)
+			fmt.Printf("%-15s%s\n", "SERVICE_NAME", orNone(s.PPPoE.ServiceName))
+		}
+		fmt.Printf("%-15s%d\n", "MTU", s.MTU)
+		fmt.Printf("%-15s%s\n", "MAC_CLONE", orNone(s.MACClone))
+	},
+}
+
+func init() {
+	wanCmd.AddCommand(wanShowCmd)
+	wanShowCmd.Flags().BoolVar(&wanShowPassword, "show-password", false, "print the PPPoE password in clear text")
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"time"
+
+	"github.com/aculclasure/tplink/watchdog"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchdogConfig           watchdog.Config
+	watchdogTCP, watchdogDNS []string
+	watchdogDNSName          string
+)
+
+// watchdogCmd represents the watchdog command
+var watchdogCmd = &cobra.Command{
+	Use:   "watchdog",
+	Short: "reboots the router when the Internet connection is lost",
+	Long: `watchdog keeps running and checks the Internet connection every --interval. A check
+fails if the router's WAN interface has no IP address or, if any --tcp or --dns
+targets are given, if none of them can be reached from this machine. After
+--threshold failed checks in a row the router is rebooted, or with --renew-first its
+WAN connection is renewed first and the router is only rebooted if the next check
+fails too. After a reboot the watchdog waits --backoff before checking again,
+doubling the wait after each reboot that does not help, and it reboots the router
+at most --max-reboots-per-day times in 24 hours, or never if it is 0. Each check and
+action is logged to standard error.
+
+Examples:
+  tplink watchdog --renew-first
+  tplink watchdog --tcp 1.1.1.1:443 --tcp 8.8.8.8:53 --dns 9.9.9.9:53 --threshold 5`,
+	Args:             cobra.NoArgs,
+	Annotations:      map[string]string{defaultLogLevelAnnotation: "info"},
+	PersistentPreRun: newClient,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := watchdogConfig
+		for _, addr := range watchdogTCP {
+			cfg.Probes = append(cfg.Probes, &watchdog.TCPProbe{Address: addr})
+		}
+		for _, server := range watchdogDNS {
+			cfg.Probes = append(cfg.Probes, &watchdog.DNSProbe{Server: server, Name: watchdogDNSName})
+		}
+		cfg.Logger = logger
+
+		w, err := watchdog.New(client, cfg)
+		if err != nil {
+			fatalf("got error creating watchdog: %v", err)
+		}
+		logger.Info("watching the Internet connection")
+		err = w.Run(ctx)
+		logger.Info("watchdog stopped", "reason", err)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchdogCmd)
+	addRouterFlags(watchdogCmd)
+	f := watchdogCmd.Flags()
+	f.DurationVar(&watchdogConfig.Interval, "interval", time.Minute, "time between two checks")
+	f.IntVar(&watchdogConfig.Threshold, "threshold", 3, "number of failed checks in a row before acting")
+	f.BoolVar(&watchdogConfig.RenewFirst, "renew-first", false, "renew the WAN connection before rebooting the router")
+	f.StringSliceVar(&watchdogTCP, "tcp", nil, "host:port to connect to as a check (repeatable)")
+	f.StringSliceVar(&watchdogDNS, "dns", nil, "DNS server host:port to resolve --dns-name with as a check (repeatable)")
+	f.StringVar(&watchdogDNSName, "dns-name", "example.com", "host name to resolve with the --dns servers")
+	f.DurationVar(&watchdogConfig.ProbeTimeout, "probe-timeout", 5*time.Second, "how long a --tcp or --dns check may take")
+	f.DurationVar(&watchdogConfig.Backoff, "backoff", 5*time.Minute, "time to wait after a reboot before checking again")
+	f.DurationVar(&watchdogConfig.MaxBackoff, "max-backoff", time.Hour, "maximum time to wait after repeated reboots")
+	f.IntVar(&watchdogConfig.MaxRebootsPerDay, "max-reboots-per-day", 3, "maximum number of reboots within 24 hours (0 to never reboot)")
+	f.DurationVar(&watchdogConfig.ActionTimeout, "action-timeout", 3*time.Minute, "how long a renew or reboot may take")
+}
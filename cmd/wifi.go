@@ -0,0 +1,55 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"strings"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+// wifiCmd represents the wifi command
+var wifiCmd = &cobra.Command{
+	Use:              "wifi",
+	Short:            "shows or changes the wireless network settings",
+	Long:             `wifi shows or changes the settings of the router's 2.4GHz and 5GHz wireless networks.`,
+	PersistentPreRun: newClient,
+}
+
+// parseBands returns the bands named by s, which is a band accepted by
+// archerc9v1.ParseBand or "both".
+func parseBands(s string) []archerc9v1.Band {
+	if strings.EqualFold(s, "both") {
+		return archerc9v1.Bands
+	}
+	band, err := archerc9v1.ParseBand(s)
+	if err != nil {
+		fatalf("got invalid --band: %v (or both)", err)
+	}
+	return []archerc9v1.Band{band}
+}
+
+func init() {
+	rootCmd.AddCommand(wifiCmd)
+	addRouterFlags(wifiCmd)
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rotateBand, rotateCharset, rotatePassphrase string
+	rotateLength                                int
+)
+
+// wifiRotatePasswordCmd represents the wifi rotate-password command
+var wifiRotatePasswordCmd = &cobra.Command{
+	Use:   "rotate-password",
+	Short: "changes the wireless passphrase to a new random one",
+	Long: `rotate-password generates a random passphrase and applies it to the wireless network
+of the given band(s), enabling WPA/WPA2-Personal security if the network is open. The
+other security settings keep their current values. For each band, the SSID, the new
+passphrase and the payload of a QR code that lets phones join the network are printed.
+
+Examples:
+  tplink wifi rotate-password --band both --length 20
+  tplink wifi rotate-password --band 2.4g --charset digits --length 12`,
+	Run: func(cmd *cobra.Command, args []string) {
+		passphrase := rotatePassphrase
+		if passphrase == "" {
+			var err error
+			passphrase, err = archerc9v1.GeneratePassphrase(rotateLength, rotateCharset)
+			if err != nil {
+				fatalf("got error generating passphrase: %v", err)
+			}
+		}
+
+		for _, band := range parseBands(rotateBand) {
+			sec, err := client.GetWirelessSecurityContext(ctx, band)
+			if err != nil {
+				fatalf("got error retrieving current %s wireless security (want a *archerc9v1.WirelessSecurity): %v", band, err)
+			}
+			settings, err := client.GetWirelessSettingsContext(ctx, band)
+			if err != nil {
+				fatalf("got error retrieving %s wireless settings (want a *archerc9v1.WirelessSettings): %v", band, err)
+			}
+
+			sec.Enabled = true
+			sec.Passphrase = passphrase
+			if err = client.SetWirelessSecurityContext(ctx, sec); err != nil {
+				fatalf("got error changing %s wireless passphrase: %v", band, err)
+			}
+
+			fmt.Printf("%-12s%s\n", "BAND", band)
+			fmt.Printf("%-12s%s\n", "SSID", settings.SSID)
+			fmt.Printf("%-12s%s\n", "PASSPHRASE", passphrase)
+			fmt.Printf("%-12s%s\n\n", "QR_PAYLOAD", archerc9v1.WiFiQRPayload(settings.SSID, passphrase, !settings.BroadcastSSID))
+		}
+	},
+}
+
+func init() {
+	wifiCmd.AddCommand(wifiRotatePasswordCmd)
+	wifiRotatePasswordCmd.Flags().StringVar(&rotateBand, "band", "both", "band(s) to change (2.4g, 5g or both)")
+	wifiRotatePasswordCmd.Flags().IntVar(&rotateLength, "length", 16, "length of the generated passphrase (8 to 63)")
+	wifiRotatePasswordCmd.Flags().StringVar(&rotateCharset, "charset", "alnum", "characters of the generated passphrase (digits, hex, alpha, alnum or printable)")
+	wifiRotatePasswordCmd.Flags().StringVar(&rotatePassphrase, "passphrase", "", "use this passphrase instead of generating one")
+}
@@ -0,0 +1,98 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/spf13/cobra"
+)
+
+var (
+	wifiSetBand, wifiSSID, wifiMode, wifiPower string
+	wifiChannel, wifiWidth                     int
+	wifiBroadcast, wifiRadio                   bool
+)
+
+// wifiSetCmd represents the wifi set command
+var wifiSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "changes the wireless network settings of a band",
+	Long: `set changes the settings of the router's radio for the given band. Only the settings
+given as flags are changed, all other settings keep their current values.
+
+Examples:
+  tplink wifi set --band 5g --channel 44
+  tplink wifi set --band 2.4g --channel 0 --width 20 --power middle`,
+	Run: func(cmd *cobra.Command, args []string) {
+		band, err := archerc9v1.ParseBand(wifiSetBand)
+		if err != nil {
+			fatalf("got invalid --band: %v", err)
+		}
+		s, err := client.GetWirelessSettingsContext(ctx, band)
+		if err != nil {
+			fatalf("got error retrieving current %s wireless settings (want a *archerc9v1.WirelessSettings): %v", band, err)
+		}
+
+		flags := cmd.Flags()
+		if flags.Changed("ssid") {
+			s.SSID = wifiSSID
+		}
+		if flags.Changed("channel") {
+			s.Channel = wifiChannel
+		}
+		if flags.Changed("width") {
+			s.ChannelWidth = wifiWidth
+		}
+		if flags.Changed("mode") {
+			s.Mode = wifiMode
+		}
+		if flags.Changed("power") {
+			s.TransmitPower = wifiPower
+		}
+		if flags.Changed("broadcast") {
+			s.BroadcastSSID = wifiBroadcast
+		}
+		if flags.Changed("radio") {
+			s.RadioEnabled = wifiRadio
+		}
+
+		if err = client.SetWirelessSettingsContext(ctx, s); err != nil {
+			fatalf("got error changing %s wireless settings: %v", band, err)
+		}
+		fmt.Printf("%s wireless settings updated!\n", band)
+	},
+}
+
+func init() {
+	wifiCmd.AddCommand(wifiSetCmd)
+	wifiSetCmd.Flags().StringVar(&wifiSetBand, "band", "", "band to change (2.4g or 5g) (required)")
+	wifiSetCmd.MarkFlagRequired("band")
+	wifiSetCmd.Flags().StringVar(&wifiSSID, "ssid", "", "network name (SSID)")
+	wifiSetCmd.Flags().IntVar(&wifiChannel, "channel", 0, "channel, 0 for auto")
+	wifiSetCmd.Flags().IntVar(&wifiWidth, "width", 0, "channel width in MHz (20, 40 or, on 5g only, 80), 0 for auto")
+	wifiSetCmd.Flags().StringVar(&wifiMode, "mode", "", "wireless mode, e.g. bgn for 2.4g or anac for 5g")
+	wifiSetCmd.Flags().StringVar(&wifiPower, "power", "", "transmit power (high, middle or low)")
+	wifiSetCmd.Flags().BoolVar(&wifiBroadcast, "broadcast", true, "broadcast the SSID")
+	wifiSetCmd.Flags().BoolVar(&wifiRadio, "radio", true, "turn the radio on (true) or off (false)")
+}
@@ -0,0 +1,72 @@
+/*
+Copyright © 2020 Andrew Culclasure
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var wifiShowBand string
+
+// wifiShowCmd represents the wifi show command
+var wifiShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "displays the wireless network settings",
+	Long: `show queries the wifi router to get the settings of its radios and prints out the
+radio state, SSID, SSID broadcast state, channel, channel width, mode, and transmit
+power for each band.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Printf("%-8s%-8s%-33s%-11s%-9s%-7s%-7s%-7s\n",
+			"BAND", "RADIO", "SSID", "BROADCAST", "CHANNEL", "WIDTH", "MODE", "POWER")
+		for _, band := range parseBands(wifiShowBand) {
+			s, err := client.GetWirelessSettingsContext(ctx, band)
+			if err != nil {
+				fatalf("got error retrieving %s wireless settings (want a *archerc9v1.WirelessSettings): %v", band, err)
+			}
+			channel, width := "auto", "auto"
+			if s.Channel != 0 {
+				channel = strconv.Itoa(s.Channel)
+			}
+			if s.ChannelWidth != 0 {
+				width = strconv.Itoa(s.ChannelWidth)
+			}
+			fmt.Printf("%-8s%-8s%-33s%-11s%-9s%-7s%-7s%-7s\n",
+				band, onOff(s.RadioEnabled), s.SSID, onOff(s.BroadcastSSID), channel, width, s.Mode, s.TransmitPower)
+		}
+	},
+}
+
+// onOff returns "on" if b is true and "off" otherwise.
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+func init() {
+	wifiCmd.AddCommand(wifiShowCmd)
+	wifiShowCmd.Flags().StringVar(&wifiShowBand, "band", "both", "band to show (2.4g, 5g or both)")
+}
@@ -23,7 +23,6 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/spf13/cobra"
 )
@@ -35,9 +34,9 @@ var wiredClientsCmd = &cobra.Command{
 	Long: `wiredClients queries the wifi router to get the currently connected wired clients and
 prints out the IP address, MAC address, and host name (if known) for each wireless client.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		wired, err := client.GetWiredConnections()
+		wired, err := client.GetWiredConnectionsContext(ctx)
 		if err != nil {
-			log.Fatalf("got error retrieving wired connections (want a []*tplinkac9v1.Connection): %v", err)
+			fatalf("got error retrieving wired connections (want a []*tplinkac9v1.Connection): %v", err)
 		}
 		if len(wired) == 0 {
 			fmt.Println("No wired connections found")
@@ -23,7 +23,6 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 
 	"github.com/spf13/cobra"
 )
@@ -36,9 +35,9 @@ var wirelessClientsCmd = &cobra.Command{
 prints out the IP address, MAC address, and host name (if known) for each wireless client.
 `,
 	Run: func(cmd *cobra.Command, args []string) {
-		wireless, err := client.GetWirelessConnections()
+		wireless, err := client.GetWirelessConnectionsContext(ctx)
 		if err != nil {
-			log.Fatalf("got error retrieving wireless connections (want a []*tplinkac9v1.Connection): %v", err)
+			fatalf("got error retrieving wireless connections (want a []*tplinkac9v1.Connection): %v", err)
 		}
 		if len(wireless) == 0 {
 			fmt.Println("No wireless connections found")
@@ -4,5 +4,6 @@ go 1.14
 
 require (
 	github.com/pkg/errors v0.9.1
+	github.com/robfig/cron/v3 v3.0.1
 	github.com/spf13/cobra v1.0.0
 )
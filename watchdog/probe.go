@@ -0,0 +1,66 @@
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"github.com/pkg/errors"
+	"net"
+)
+
+// Probe checks whether a target beyond the router can be reached.
+type Probe interface {
+	// Probe returns nil if the target can be reached before ctx is done or
+	// returns an error otherwise.
+	Probe(ctx context.Context) error
+	// String describes the probe in log messages.
+	String() string
+}
+
+// TCPProbe checks that a TCP connection to Address, given as host:port, can be
+// established.
+type TCPProbe struct {
+	Address string
+}
+
+// Probe implements Probe.
+func (p *TCPProbe) Probe(ctx context.Context) error {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", p.Address)
+	if err != nil {
+		return errors.Wrap(err, "got error connecting to "+p.Address)
+	}
+	return conn.Close()
+}
+
+func (p *TCPProbe) String() string {
+	return "tcp " + p.Address
+}
+
+// DNSProbe checks that the DNS server at Server, given as host:port, resolves
+// the host name Name.
+type DNSProbe struct {
+	Server, Name string
+}
+
+// Probe implements Probe.
+func (p *DNSProbe) Probe(ctx context.Context) error {
+	r := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, p.Server)
+		},
+	}
+	addrs, err := r.LookupHost(ctx, p.Name)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("got error resolving %s with %s", p.Name, p.Server))
+	}
+	if len(addrs) == 0 {
+		return fmt.Errorf("got no addresses for %s from %s (want at least one)", p.Name, p.Server)
+	}
+	return nil
+}
+
+func (p *DNSProbe) String() string {
+	return "dns " + p.Name + "@" + p.Server
+}
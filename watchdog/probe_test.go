@@ -0,0 +1,132 @@
+package watchdog
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+)
+
+// serveDNS answers the A queries received on conn with address ip and all other
+// queries with an empty answer, until conn is closed.
+func serveDNS(conn net.PacketConn, ip net.IP) {
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+		if n < 12 {
+			continue
+		}
+		// The question is the name followed by the type and the class.
+		end := 12
+		for end < n && buf[end] != 0 {
+			end += int(buf[end]) + 1
+		}
+		end += 5
+		if end > n {
+			continue
+		}
+		qtype := binary.BigEndian.Uint16(buf[end-4:])
+
+		resp := append([]byte{}, buf[:end]...)
+		resp[2], resp[3] = 0x81, 0x80 // response, recursion desired and available
+		binary.BigEndian.PutUint16(resp[6:], 0)
+		binary.BigEndian.PutUint16(resp[8:], 0)
+		binary.BigEndian.PutUint16(resp[10:], 0)
+		if qtype == 1 {
+			binary.BigEndian.PutUint16(resp[6:], 1)
+			resp = append(resp, 0xc0, 12, 0, 1, 0, 1, 0, 0, 0, 60, 0, 4)
+			resp = append(resp, ip.To4()...)
+		}
+		conn.WriteTo(resp, addr)
+	}
+}
+
+func TestTCPProbe(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("FAIL: got error listening on a local port: %v", err)
+	}
+	open := l.Addr().String()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+	defer l.Close()
+
+	closedListener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("FAIL: got error listening on a local port: %v", err)
+	}
+	closed := closedListener.Addr().String()
+	closedListener.Close()
+
+	testCases := []*struct {
+		description string
+		address     string
+		expectError bool
+	}{
+		{description: "Closed port", address: closed, expectError: true},
+		{description: "Open port", address: open},
+	}
+
+	for _, tt := range testCases {
+		p := &TCPProbe{Address: tt.address}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err := p.Probe(ctx)
+		cancel()
+		if tt.expectError && err == nil {
+			t.Fatalf("FAIL: %s\n\t%s did not return an expected error", tt.description, p)
+		}
+		if !tt.expectError && err != nil {
+			t.Fatalf("FAIL: %s\n\t%s returned an unexpected error: %v", tt.description, p, err)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestDNSProbe(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("FAIL: got error listening on a local port: %v", err)
+	}
+	defer conn.Close()
+	go serveDNS(conn, net.IPv4(192, 0, 2, 1))
+
+	silent, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("FAIL: got error listening on a local port: %v", err)
+	}
+	defer silent.Close()
+
+	testCases := []*struct {
+		description string
+		server      string
+		expectError bool
+	}{
+		{description: "Unresponsive server", server: silent.LocalAddr().String(), expectError: true},
+		{description: "Resolving server", server: conn.LocalAddr().String()},
+	}
+
+	for _, tt := range testCases {
+		p := &DNSProbe{Server: tt.server, Name: "example.com"}
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		err := p.Probe(ctx)
+		cancel()
+		if tt.expectError && err == nil {
+			t.Fatalf("FAIL: %s\n\t%s did not return an expected error", tt.description, p)
+		}
+		if !tt.expectError && err != nil {
+			t.Fatalf("FAIL: %s\n\t%s returned an unexpected error: %v", tt.description, p, err)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
@@ -0,0 +1,226 @@
+// Package watchdog restores a TP Link router's Internet connection when it is
+// lost, by renewing the WAN connection or rebooting the router.
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"github.com/aculclasure/tplink/archerc9v1"
+	"github.com/pkg/errors"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// Router is the part of *archerc9v1.Client the Watchdog uses.
+type Router interface {
+	GetStatusContext(ctx context.Context) (*archerc9v1.Status, error)
+	RenewWANContext(ctx context.Context, timeout time.Duration) (*archerc9v1.WANStatus, error)
+	ConnectPPPoEContext(ctx context.Context, timeout time.Duration) (*archerc9v1.WANStatus, error)
+	RebootAndWait(ctx context.Context, timeout time.Duration) (time.Duration, error)
+}
+
+// Config configures a Watchdog. The zero values of the durations and counts
+// are replaced by the defaults noted below.
+type Config struct {
+	// Interval is the time between two checks (default 1m).
+	Interval time.Duration
+	// Threshold is the number of consecutive failed checks after which the
+	// watchdog acts (default 3).
+	Threshold int
+	// RenewFirst makes the watchdog renew the WAN connection when the threshold
+	// is reached and only reboot the router if the next check fails as well.
+	// Static IP connections cannot be renewed and are always rebooted.
+	RenewFirst bool
+	// Probes are checked in addition to the router's WAN status. A check fails
+	// if the WAN interface has no address or if all of the probes fail.
+	Probes []Probe
+	// ProbeTimeout bounds each probe (default 5s).
+	ProbeTimeout time.Duration
+	// Backoff is the time to wait after a reboot before checking again
+	// (default 5m). It doubles after each reboot that does not restore the
+	// connection, up to MaxBackoff (default 1h).
+	Backoff, MaxBackoff time.Duration
+	// MaxRebootsPerDay caps the number of reboots within any 24 hours. Once
+	// reached, the watchdog only logs failed checks. 0 turns rebooting off and a
+	// negative value selects the default of 3.
+	MaxRebootsPerDay int
+	// ActionTimeout bounds how long a renew or reboot may take (default 3m).
+	ActionTimeout time.Duration
+	// Logger logs the failed checks and the actions. If nil, a Logger that logs
+	// messages of the info level or more severe ones to os.Stderr is used.
+	Logger archerc9v1.Logger
+}
+
+// Watchdog periodically checks a router's Internet connection and renews it or
+// reboots the router after repeated failures.
+type Watchdog struct {
+	router Router
+	config Config
+	now    func() time.Time
+
+	failures int
+	renewed  bool
+	backoff  time.Duration
+	reboots  []time.Time
+}
+
+// New returns a Watchdog for router with the given configuration, or an error
+// if router is nil or the configuration is invalid.
+func New(router Router, config Config) (*Watchdog, error) {
+	if router == nil {
+		return nil, errors.New("got nil router (want a router to watch)")
+	}
+	for _, d := range []*struct {
+		value *time.Duration
+		def   time.Duration
+	}{
+		{&config.Interval, time.Minute},
+		{&config.ProbeTimeout, 5 * time.Second},
+		{&config.Backoff, 5 * time.Minute},
+		{&config.MaxBackoff, time.Hour},
+		{&config.ActionTimeout, 3 * time.Minute},
+	} {
+		if *d.value < 0 {
+			return nil, fmt.Errorf("got negative duration %s in watchdog config (want 0 for the default or more)", *d.value)
+		}
+		if *d.value == 0 {
+			*d.value = d.def
+		}
+	}
+	if config.Threshold < 0 {
+		return nil, fmt.Errorf("got negative threshold %d in watchdog config (want 0 for the default or more)",
+			config.Threshold)
+	}
+	if config.Threshold == 0 {
+		config.Threshold = 3
+	}
+	if config.MaxRebootsPerDay < 0 {
+		config.MaxRebootsPerDay = 3
+	}
+	if config.MaxBackoff < config.Backoff {
+		config.MaxBackoff = config.Backoff
+	}
+	if config.Logger == nil {
+		config.Logger = archerc9v1.NewStdLogger(log.New(os.Stderr, "", log.LstdFlags), archerc9v1.LevelInfo,
+			archerc9v1.TextFormat)
+	}
+	return &Watchdog{router: router, config: config, now: time.Now, backoff: config.Backoff}, nil
+}
+
+// Run checks the connection every interval, and acts on repeated failures, until
+// ctx is done, and then returns ctx's error.
+func (w *Watchdog) Run(ctx context.Context) error {
+	for {
+		wait := w.Step(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// Step checks the connection once, renews the WAN connection or reboots the
+// router if the check failed often enough, and returns how long to wait before
+// the next step.
+func (w *Watchdog) Step(ctx context.Context) time.Duration {
+	wan, err := w.Check(ctx)
+	if err == nil {
+		if w.failures > 0 {
+			w.config.Logger.Info("connection restored", "failed_checks", w.failures)
+		}
+		w.failures, w.renewed, w.backoff = 0, false, w.config.Backoff
+		return w.config.Interval
+	}
+
+	w.failures++
+	w.config.Logger.Warn("connection check failed", "failures", w.failures, "threshold", w.config.Threshold,
+		"error", err)
+	if w.failures < w.config.Threshold {
+		return w.config.Interval
+	}
+
+	if w.config.RenewFirst && !w.renewed && wan != nil && wan.ConnectionType != archerc9v1.WANStaticIP {
+		w.renewed = true
+		w.renew(ctx, wan.ConnectionType)
+		return w.config.Interval
+	}
+
+	if w.config.MaxRebootsPerDay == 0 {
+		w.config.Logger.Warn("not rebooting, rebooting is turned off")
+		return w.config.Interval
+	}
+	if n := w.rebootsInLastDay(); n >= w.config.MaxRebootsPerDay {
+		w.config.Logger.Warn("not rebooting, reached the maximum number of reboots in the last 24h", "reboots", n)
+		return w.config.Interval
+	}
+	w.config.Logger.Warn("rebooting the router")
+	w.reboots = append(w.reboots, w.now())
+	w.failures, w.renewed = 0, false
+	downtime, err := w.router.RebootAndWait(ctx, w.config.ActionTimeout)
+	if err != nil {
+		w.config.Logger.Error("got error rebooting the router", "error", err)
+	} else {
+		w.config.Logger.Info("router back up", "downtime", downtime.Round(time.Second))
+	}
+
+	wait := w.backoff
+	if w.backoff *= 2; w.backoff > w.config.MaxBackoff {
+		w.backoff = w.config.MaxBackoff
+	}
+	return wait
+}
+
+// Check returns nil if the router's WAN interface has an address and, if there
+// are probes, at least one of them succeeds, or returns an error otherwise. The
+// WAN status is returned if the router could be reached.
+func (w *Watchdog) Check(ctx context.Context) (*archerc9v1.WANStatus, error) {
+	status, err := w.router.GetStatusContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "got error getting router status")
+	}
+	if !status.WAN.Connected() {
+		return status.WAN, errors.New("got WAN interface without an IP address")
+	}
+	if len(w.config.Probes) == 0 {
+		return status.WAN, nil
+	}
+
+	var failed []string
+	for _, p := range w.config.Probes {
+		pctx, cancel := context.WithTimeout(ctx, w.config.ProbeTimeout)
+		err := p.Probe(pctx)
+		cancel()
+		if err == nil {
+			return status.WAN, nil
+		}
+		failed = append(failed, fmt.Sprintf("%s: %v", p, err))
+	}
+	return status.WAN, fmt.Errorf("got all probes failing: %s", strings.Join(failed, "; "))
+}
+
+// renew renews the WAN connection of type t.
+func (w *Watchdog) renew(ctx context.Context, t archerc9v1.WANConnectionType) {
+	w.config.Logger.Warn("renewing the WAN connection", "type", t)
+	var err error
+	if t == archerc9v1.WANPPPoE {
+		_, err = w.router.ConnectPPPoEContext(ctx, w.config.ActionTimeout)
+	} else {
+		_, err = w.router.RenewWANContext(ctx, w.config.ActionTimeout)
+	}
+	if err != nil {
+		w.config.Logger.Error("got error renewing the WAN connection", "error", err)
+	}
+}
+
+// rebootsInLastDay forgets the reboots older than 24 hours and returns the
+// number of the remaining ones.
+func (w *Watchdog) rebootsInLastDay() int {
+	cutoff := w.now().Add(-24 * time.Hour)
+	for len(w.reboots) > 0 && w.reboots[0].Before(cutoff) {
+		w.reboots = w.reboots[1:]
+	}
+	return len(w.reboots)
+}
@@ -0,0 +1,302 @@
+package watchdog
+
+import (
+	"context"
+	"fmt"
+	"github.com/aculclasure/tplink/archerc9v1"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var _ Router = (*archerc9v1.Client)(nil)
+
+var quietLogger = archerc9v1.NewStdLogger(log.New(ioutil.Discard, "", 0), archerc9v1.LevelDebug, archerc9v1.TextFormat)
+
+// fakeRouter is a Router whose WAN interface has an address on the calls to
+// GetStatus for which connected holds true. The last value of connected is
+// repeated for further calls.
+type fakeRouter struct {
+	wanType                   archerc9v1.WANConnectionType
+	connected                 []bool
+	statusCalls               int
+	renews, connects, reboots int
+}
+
+func (r *fakeRouter) GetStatusContext(context.Context) (*archerc9v1.Status, error) {
+	i := r.statusCalls
+	if i >= len(r.connected) {
+		i = len(r.connected) - 1
+	}
+	r.statusCalls++
+	wan := &archerc9v1.WANStatus{ConnectionType: r.wanType}
+	if r.connected[i] {
+		wan.IPAddress = "203.0.113.7"
+	}
+	return &archerc9v1.Status{WAN: wan}, nil
+}
+
+func (r *fakeRouter) RenewWANContext(context.Context, time.Duration) (*archerc9v1.WANStatus, error) {
+	r.renews++
+	return nil, nil
+}
+
+func (r *fakeRouter) ConnectPPPoEContext(context.Context, time.Duration) (*archerc9v1.WANStatus, error) {
+	r.connects++
+	return nil, nil
+}
+
+func (r *fakeRouter) RebootAndWait(context.Context, time.Duration) (time.Duration, error) {
+	r.reboots++
+	return time.Second, nil
+}
+
+// failingProbe is a Probe that always fails.
+type failingProbe struct{}
+
+func (failingProbe) Probe(context.Context) error { return fmt.Errorf("unreachable") }
+
+func (failingProbe) String() string { return "failing" }
+
+func TestNew(t *testing.T) {
+	testCases := []*struct {
+		description string
+		router      Router
+		config      Config
+		expectError bool
+	}{
+		{
+			description: "Nil router",
+			expectError: true,
+		},
+		{
+			description: "Negative interval",
+			router:      &fakeRouter{},
+			config:      Config{Interval: -time.Second},
+			expectError: true,
+		},
+		{
+			description: "Negative threshold",
+			router:      &fakeRouter{},
+			config:      Config{Threshold: -1},
+			expectError: true,
+		},
+		{
+			description: "Default config",
+			router:      &fakeRouter{},
+			config:      Config{MaxRebootsPerDay: -1},
+		},
+	}
+
+	for _, tt := range testCases {
+		w, err := New(tt.router, tt.config)
+		if tt.expectError {
+			if err == nil {
+				t.Fatalf("FAIL: %s\n\tNew(%v, %+v) did not return an expected error", tt.description, tt.router, tt.config)
+			}
+		} else {
+			if err != nil {
+				t.Fatalf("FAIL: %s\n\tNew(%v, %+v) returned an unexpected error: %v", tt.description, tt.router, tt.config, err)
+			}
+			if w.config.Interval != time.Minute || w.config.Threshold != 3 || w.config.MaxRebootsPerDay != 3 {
+				t.Fatalf("FAIL: %s\n\tNew(%v, %+v) did not apply the defaults: %+v",
+					tt.description, tt.router, tt.config, w.config)
+			}
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestWatchdog_Step(t *testing.T) {
+	testCases := []*struct {
+		description      string
+		router           *fakeRouter
+		config           Config
+		steps            int
+		expectedRenews   int
+		expectedConnects int
+		expectedReboots  int
+		expectedWait     time.Duration
+	}{
+		{
+			description:  "Connected",
+			router:       &fakeRouter{connected: []bool{true}},
+			config:       Config{Interval: time.Second, Threshold: 2},
+			steps:        5,
+			expectedWait: time.Second,
+		},
+		{
+			description:  "Failures below threshold",
+			router:       &fakeRouter{connected: []bool{false, true, false, true}},
+			config:       Config{Interval: time.Second, Threshold: 2},
+			steps:        4,
+			expectedWait: time.Second,
+		},
+		{
+			description:     "Reboot at threshold",
+			router:          &fakeRouter{connected: []bool{false, false, true}},
+			config:          Config{Interval: time.Second, Threshold: 2, Backoff: time.Minute, MaxRebootsPerDay: -1},
+			steps:           2,
+			expectedReboots: 1,
+			expectedWait:    time.Minute,
+		},
+		{
+			description:     "Backoff doubles up to maximum",
+			router:          &fakeRouter{connected: []bool{false}},
+			config:          Config{Threshold: 1, Backoff: time.Minute, MaxBackoff: 3 * time.Minute, MaxRebootsPerDay: 10},
+			steps:           4,
+			expectedReboots: 4,
+			expectedWait:    3 * time.Minute,
+		},
+		{
+			description:     "Reboot cap",
+			router:          &fakeRouter{connected: []bool{false}},
+			config:          Config{Interval: time.Second, Threshold: 1, MaxRebootsPerDay: 2},
+			steps:           6,
+			expectedReboots: 2,
+			expectedWait:    time.Second,
+		},
+		{
+			description:  "Reboots turned off",
+			router:       &fakeRouter{connected: []bool{false}},
+			config:       Config{Interval: time.Second, Threshold: 1},
+			steps:        3,
+			expectedWait: time.Second,
+		},
+		{
+			description:    "Renew restores connection",
+			router:         &fakeRouter{connected: []bool{false, false, true}},
+			config:         Config{Interval: time.Second, Threshold: 2, RenewFirst: true},
+			steps:          3,
+			expectedRenews: 1,
+			expectedWait:   time.Second,
+		},
+		{
+			description:      "PPPoE reconnect then reboot",
+			router:           &fakeRouter{wanType: archerc9v1.WANPPPoE, connected: []bool{false}},
+			config:           Config{Threshold: 2, RenewFirst: true, Backoff: time.Minute, MaxRebootsPerDay: -1},
+			steps:            3,
+			expectedConnects: 1,
+			expectedReboots:  1,
+			expectedWait:     time.Minute,
+		},
+		{
+			description:     "Static IP is not renewed",
+			router:          &fakeRouter{wanType: archerc9v1.WANStaticIP, connected: []bool{false}},
+			config:          Config{Threshold: 1, RenewFirst: true, MaxRebootsPerDay: -1},
+			steps:           1,
+			expectedReboots: 1,
+			expectedWait:    5 * time.Minute,
+		},
+		{
+			description:     "All probes failing",
+			router:          &fakeRouter{connected: []bool{true}},
+			config:          Config{Threshold: 1, Probes: []Probe{failingProbe{}}, Backoff: time.Minute, MaxRebootsPerDay: -1},
+			steps:           1,
+			expectedReboots: 1,
+			expectedWait:    time.Minute,
+		},
+	}
+
+	for _, tt := range testCases {
+		tt.config.Logger = quietLogger
+		w, err := New(tt.router, tt.config)
+		if err != nil {
+			t.Fatalf("FAIL: %s\n\tNew() returned an unexpected error: %v", tt.description, err)
+		}
+		var wait time.Duration
+		for i := 0; i < tt.steps; i++ {
+			wait = w.Step(context.Background())
+		}
+		if tt.router.renews != tt.expectedRenews || tt.router.connects != tt.expectedConnects ||
+			tt.router.reboots != tt.expectedReboots {
+			t.Fatalf("FAIL: %s\n\tStep() renewed %d, reconnected %d and rebooted %d time(s), want %d, %d and %d",
+				tt.description, tt.router.renews, tt.router.connects, tt.router.reboots,
+				tt.expectedRenews, tt.expectedConnects, tt.expectedReboots)
+		}
+		if wait != tt.expectedWait {
+			t.Fatalf("FAIL: %s\n\tStep() returned wait %s, want %s", tt.description, wait, tt.expectedWait)
+		}
+		t.Logf("PASS: %s", tt.description)
+	}
+}
+
+func TestWatchdog_rebootCapResetsAfterADay(t *testing.T) {
+	router := &fakeRouter{connected: []bool{false}}
+	w, _ := New(router, Config{Threshold: 1, MaxRebootsPerDay: 1, Logger: quietLogger})
+	now := time.Date(2020, 6, 1, 3, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+
+	w.Step(context.Background())
+	w.Step(context.Background())
+	now = now.Add(25 * time.Hour)
+	w.Step(context.Background())
+	if router.reboots != 2 {
+		t.Fatalf("FAIL: Reboot cap resets after a day\n\tStep() rebooted %d time(s), want 2", router.reboots)
+	}
+	t.Logf("PASS: Reboot cap resets after a day")
+}
+
+// TestWatchdog_Run runs a watchdog against a local fake router whose WAN
+// interface has no address, using the archerc9v1.Client.
+func TestWatchdog_Run(t *testing.T) {
+	var mu sync.Mutex
+	reboots := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/userRpm/StatusRpm.htm":
+			fmt.Fprint(w, disconnectedStatusPage)
+		case "/userRpm/SysRebootRpm.htm":
+			mu.Lock()
+			reboots++
+			mu.Unlock()
+			fmt.Fprint(w, "Rebooting... Completed!")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := archerc9v1.NewClient(srv.URL, archerc9v1.WithCredentials("admin", "admin"), archerc9v1.WithLogger(quietLogger))
+	if err != nil {
+		t.Fatalf("FAIL: archerc9v1.New() returned an unexpected error: %v", err)
+	}
+	w, err := New(client, Config{
+		Interval:         time.Millisecond,
+		Threshold:        2,
+		Backoff:          time.Millisecond,
+		MaxRebootsPerDay: 1,
+		ActionTimeout:    10 * time.Millisecond,
+		Logger:           quietLogger,
+	})
+	if err != nil {
+		t.Fatalf("FAIL: New() returned an unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err = w.Run(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("FAIL: Run() returned %v, want %v", err, context.DeadlineExceeded)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if reboots != 1 {
+		t.Fatalf("FAIL: Run() rebooted the router %d time(s), want 1", reboots)
+	}
+	t.Logf("PASS: Run against a local router")
+}
+
+var disconnectedStatusPage = strings.Join([]string{
+	`<script type="text/javascript">`,
+	`var statusPara = new Array("3.16.0 Build 160906 Rel.61584n", "Archer C9 v1 00000000", 60, 0,0 );`,
+	`var lanPara = new Array("50-C7-BF-00-00-01", "192.168.0.1", "255.255.255.0", 0,0 );`,
+	`var wanPara = new Array(0, "50-C7-BF-00-00-02", "0.0.0.0", "0.0.0.0", "0.0.0.0", "0.0.0.0 , 0.0.0.0", 0,0 );`,
+	`var wlanPara = new Array(1, "HomeNet", 6, 5, 1, "50-C7-BF-00-00-03", 0,0 );`,
+	`var wlan5GPara = new Array(0, "HomeNet_5G", 149, 3, 4, "50-C7-BF-00-00-04", 0,0 );`,
+	`</script>`,
+}, "\n")